@@ -0,0 +1,271 @@
+package namecheap
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+)
+
+const (
+	usersAddressCreate     = "namecheap.users.address.create"
+	usersAddressDelete     = "namecheap.users.address.delete"
+	usersAddressGetInfo    = "namecheap.users.address.getInfo"
+	usersAddressGetList    = "namecheap.users.address.getList"
+	usersAddressSetDefault = "namecheap.users.address.setDefault"
+	usersAddressUpdate     = "namecheap.users.address.update"
+)
+
+// UserAddress is an entry of the user's address book, as returned by
+// 'users.address.getInfo' and sent by 'users.address.create' and 'update'.
+type UserAddress struct {
+	ID                  int    `xml:"AddressId"`
+	Name                string `xml:"AddressName"`
+	IsDefault           bool   `xml:"Default_YN"`
+	FirstName           string `xml:"FirstName"`
+	LastName            string `xml:"LastName"`
+	JobTitle            string `xml:"JobTitle"`
+	Organization        string `xml:"Organization"`
+	Address1            string `xml:"Address1"`
+	Address2            string `xml:"Address2"`
+	City                string `xml:"City"`
+	StateProvince       string `xml:"StateProvince"`
+	StateProvinceChoice string `xml:"StateProvinceChoice"`
+	Zip                 string `xml:"Zip"`
+	Country             string `xml:"Country"`
+	Phone               string `xml:"Phone"`
+	PhoneExt            string `xml:"PhoneExt"`
+	Fax                 string `xml:"Fax"`
+	EmailAddress        string `xml:"EmailAddress"`
+}
+
+// UserAddressListEntry represents the data returned by 'users.address.getList'
+type UserAddressListEntry struct {
+	ID        int    `xml:"AddressId,attr"`
+	Name      string `xml:"AddressName,attr"`
+	IsDefault bool   `xml:"IsDefault,attr"`
+}
+
+// UserAddressResult represents the data returned by 'users.address.create',
+// 'users.address.update' and 'users.address.setDefault'
+type UserAddressResult struct {
+	Success bool   `xml:"Success,attr"`
+	ID      int    `xml:"AddressId,attr"`
+	Name    string `xml:"AddressName,attr"`
+}
+
+// UserAddressDeleteResult represents the data returned by 'users.address.delete'
+type UserAddressDeleteResult struct {
+	Success   bool   `xml:"Success,attr"`
+	ProfileID int    `xml:"ProfileId,attr"`
+	UserName  string `xml:"UserName,attr"`
+}
+
+// addValues adds the address fields to the passed in url.Values.
+func (address *UserAddress) addValues(u url.Values) {
+	defaultYN := "0"
+	if address.IsDefault {
+		defaultYN = "1"
+	}
+	u.Set("AddressName", address.Name)
+	u.Set("DefaultYN", defaultYN)
+	u.Set("EmailAddress", address.EmailAddress)
+	u.Set("FirstName", address.FirstName)
+	u.Set("LastName", address.LastName)
+	u.Set("Address1", address.Address1)
+	u.Set("City", address.City)
+	u.Set("StateProvince", address.StateProvince)
+	u.Set("Zip", address.Zip)
+	u.Set("Country", address.Country)
+	u.Set("Phone", address.Phone)
+	for name, value := range map[string]string{
+		"JobTitle":            address.JobTitle,
+		"Organization":        address.Organization,
+		"Address2":            address.Address2,
+		"StateProvinceChoice": address.StateProvinceChoice,
+		"PhoneExt":            address.PhoneExt,
+		"Fax":                 address.Fax,
+	} {
+		if value != "" {
+			u.Set(name, value)
+		}
+	}
+}
+
+// ToRegistrant returns a Registrant using the address for all four contacts.
+func (address *UserAddress) ToRegistrant() *Registrant {
+	return newRegistrant(
+		address.FirstName, address.LastName,
+		address.Address1, address.Address2,
+		address.City, address.StateProvince, address.Zip, address.Country,
+		address.Phone, address.EmailAddress,
+	)
+}
+
+func (client *Client) UsersAddressCreate(address *UserAddress) (*UserAddressResult, error) {
+	return client.UsersAddressCreateContext(context.Background(), address)
+}
+
+// UsersAddressCreateContext is like UsersAddressCreate but uses ctx for the underlying request.
+func (client *Client) UsersAddressCreateContext(ctx context.Context, address *UserAddress) (*UserAddressResult, error) {
+	requestInfo := &ApiRequest{
+		command: usersAddressCreate,
+		method:  "POST",
+		params:  url.Values{},
+	}
+	address.addValues(requestInfo.params)
+
+	resp, err := client.do(ctx, requestInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.UsersAddressCreate, nil
+}
+
+func (client *Client) UsersAddressDelete(addressID int) (*UserAddressDeleteResult, error) {
+	return client.UsersAddressDeleteContext(context.Background(), addressID)
+}
+
+// UsersAddressDeleteContext is like UsersAddressDelete but uses ctx for the underlying request.
+func (client *Client) UsersAddressDeleteContext(ctx context.Context, addressID int) (*UserAddressDeleteResult, error) {
+	requestInfo := &ApiRequest{
+		command: usersAddressDelete,
+		method:  "POST",
+		params:  url.Values{},
+	}
+	requestInfo.params.Set("AddressId", strconv.Itoa(addressID))
+
+	resp, err := client.do(ctx, requestInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.UsersAddressDelete, nil
+}
+
+func (client *Client) UsersAddressGetInfo(addressID int) (*UserAddress, error) {
+	return client.UsersAddressGetInfoContext(context.Background(), addressID)
+}
+
+// UsersAddressGetInfoContext is like UsersAddressGetInfo but uses ctx for the underlying request.
+func (client *Client) UsersAddressGetInfoContext(ctx context.Context, addressID int) (*UserAddress, error) {
+	requestInfo := &ApiRequest{
+		command: usersAddressGetInfo,
+		method:  "POST",
+		params:  url.Values{},
+	}
+	requestInfo.params.Set("AddressId", strconv.Itoa(addressID))
+
+	resp, err := client.do(ctx, requestInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.UsersAddressInfo, nil
+}
+
+func (client *Client) UsersAddressGetList() ([]UserAddressListEntry, error) {
+	return client.UsersAddressGetListContext(context.Background())
+}
+
+// UsersAddressGetListContext is like UsersAddressGetList but uses ctx for the underlying request.
+func (client *Client) UsersAddressGetListContext(ctx context.Context) ([]UserAddressListEntry, error) {
+	requestInfo := &ApiRequest{
+		command: usersAddressGetList,
+		method:  "POST",
+		params:  url.Values{},
+	}
+
+	resp, err := client.do(ctx, requestInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.UsersAddressList, nil
+}
+
+func (client *Client) UsersAddressSetDefault(addressID int) (*UserAddressResult, error) {
+	return client.UsersAddressSetDefaultContext(context.Background(), addressID)
+}
+
+// UsersAddressSetDefaultContext is like UsersAddressSetDefault but uses ctx for the underlying request.
+func (client *Client) UsersAddressSetDefaultContext(ctx context.Context, addressID int) (*UserAddressResult, error) {
+	requestInfo := &ApiRequest{
+		command: usersAddressSetDefault,
+		method:  "POST",
+		params:  url.Values{},
+	}
+	requestInfo.params.Set("AddressId", strconv.Itoa(addressID))
+
+	resp, err := client.do(ctx, requestInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.UsersAddressSetDefault, nil
+}
+
+// UsersAddressUpdate replaces the stored address with ID address.ID.
+func (client *Client) UsersAddressUpdate(address *UserAddress) (*UserAddressResult, error) {
+	return client.UsersAddressUpdateContext(context.Background(), address)
+}
+
+// UsersAddressUpdateContext is like UsersAddressUpdate but uses ctx for the underlying request.
+func (client *Client) UsersAddressUpdateContext(ctx context.Context, address *UserAddress) (*UserAddressResult, error) {
+	requestInfo := &ApiRequest{
+		command: usersAddressUpdate,
+		method:  "POST",
+		params:  url.Values{},
+	}
+	requestInfo.params.Set("AddressId", strconv.Itoa(address.ID))
+	address.addValues(requestInfo.params)
+
+	resp, err := client.do(ctx, requestInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.UsersAddressUpdate, nil
+}
+
+// NewRegistrantFromAddress associates the stored address with the given ID
+// with the client, for use by DomainCreate.
+func (client *Client) NewRegistrantFromAddress(addressID int) error {
+	return client.NewRegistrantFromAddressContext(context.Background(), addressID)
+}
+
+// NewRegistrantFromAddressContext is like NewRegistrantFromAddress but uses ctx for the underlying request.
+func (client *Client) NewRegistrantFromAddressContext(ctx context.Context, addressID int) error {
+	address, err := client.UsersAddressGetInfoContext(ctx, addressID)
+	if err != nil {
+		return err
+	}
+	if address == nil {
+		return errors.New("address information was not returned")
+	}
+
+	client.Registrant = address.ToRegistrant()
+	return nil
+}
+
+// NewRegistrantFromDefaultAddress associates the user's default address with
+// the client, for use by DomainCreate.
+func (client *Client) NewRegistrantFromDefaultAddress() error {
+	return client.NewRegistrantFromDefaultAddressContext(context.Background())
+}
+
+// NewRegistrantFromDefaultAddressContext is like NewRegistrantFromDefaultAddress but uses ctx for the underlying requests.
+func (client *Client) NewRegistrantFromDefaultAddressContext(ctx context.Context) error {
+	addresses, err := client.UsersAddressGetListContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, address := range addresses {
+		if address.IsDefault {
+			return client.NewRegistrantFromAddressContext(ctx, address.ID)
+		}
+	}
+	return errors.New("the user has no default address")
+}
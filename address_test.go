@@ -0,0 +1,324 @@
+package namecheap
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+const addressGetInfoXML = `<?xml version="1.0" encoding="UTF-8"?>
+<ApiResponse xmlns="http://api.namecheap.com/xml.response" Status="OK">
+  <Errors />
+  <RequestedCommand>namecheap.users.address.getInfo</RequestedCommand>
+  <CommandResponse Type="namecheap.users.address.getInfo">
+    <GetAddressInfoResult>
+      <AddressId>1145</AddressId>
+      <UserName>anUser</UserName>
+      <AddressName>Office</AddressName>
+      <Default_YN>true</Default_YN>
+      <FirstName>John</FirstName>
+      <LastName>Smith</LastName>
+      <JobTitle>Engineer</JobTitle>
+      <Organization>NameCheap.com</Organization>
+      <Address1>8939 S.cross Blvd</Address1>
+      <Address2 />
+      <City>CA</City>
+      <StateProvince>CA</StateProvince>
+      <StateProvinceChoice>S</StateProvinceChoice>
+      <Zip>90045</Zip>
+      <Country>US</Country>
+      <Phone>+1.6613102107</Phone>
+      <PhoneExt />
+      <EmailAddress>john@gmail.com</EmailAddress>
+    </GetAddressInfoResult>
+  </CommandResponse>
+  <Server>SERVER-NAME</Server>
+  <GMTTimeDifference>+5</GMTTimeDifference>
+  <ExecutionTime>0.024</ExecutionTime>
+</ApiResponse>`
+
+const addressGetListXML = `<?xml version="1.0" encoding="UTF-8"?>
+<ApiResponse xmlns="http://api.namecheap.com/xml.response" Status="OK">
+  <Errors />
+  <RequestedCommand>namecheap.users.address.getList</RequestedCommand>
+  <CommandResponse Type="namecheap.users.address.getList">
+    <AddressGetListResult>
+      <List AddressId="0" AddressName="Primary Address" IsDefault="false" />
+      <List AddressId="1145" AddressName="Office" IsDefault="true" />
+    </AddressGetListResult>
+  </CommandResponse>
+  <Server>SERVER-NAME</Server>
+  <GMTTimeDifference>+5</GMTTimeDifference>
+  <ExecutionTime>0.024</ExecutionTime>
+</ApiResponse>`
+
+var office = UserAddress{
+	ID:                  1145,
+	Name:                "Office",
+	IsDefault:           true,
+	FirstName:           "John",
+	LastName:            "Smith",
+	JobTitle:            "Engineer",
+	Organization:        "NameCheap.com",
+	Address1:            "8939 S.cross Blvd",
+	City:                "CA",
+	StateProvince:       "CA",
+	StateProvinceChoice: "S",
+	Zip:                 "90045",
+	Country:             "US",
+	Phone:               "+1.6613102107",
+	EmailAddress:        "john@gmail.com",
+}
+
+func TestUsersAddressCreate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	respXML := `<?xml version="1.0" encoding="UTF-8"?>
+<ApiResponse xmlns="http://api.namecheap.com/xml.response" Status="OK">
+  <Errors />
+  <RequestedCommand>namecheap.users.address.create</RequestedCommand>
+  <CommandResponse Type="namecheap.users.address.create">
+    <AddressCreateResult Success="true" AddressId="1145" AddressName="Office" />
+  </CommandResponse>
+  <Server>SERVER-NAME</Server>
+  <GMTTimeDifference>+5</GMTTimeDifference>
+  <ExecutionTime>0.024</ExecutionTime>
+</ApiResponse>`
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		correctParams := fillDefaultParams(url.Values{})
+		correctParams.Set("Command", "namecheap.users.address.create")
+		correctParams.Set("AddressName", "Office")
+		correctParams.Set("DefaultYN", "1")
+		correctParams.Set("FirstName", "John")
+		correctParams.Set("LastName", "Smith")
+		correctParams.Set("JobTitle", "Engineer")
+		correctParams.Set("Organization", "NameCheap.com")
+		correctParams.Set("Address1", "8939 S.cross Blvd")
+		correctParams.Set("City", "CA")
+		correctParams.Set("StateProvince", "CA")
+		correctParams.Set("StateProvinceChoice", "S")
+		correctParams.Set("Zip", "90045")
+		correctParams.Set("Country", "US")
+		correctParams.Set("Phone", "+1.6613102107")
+		correctParams.Set("EmailAddress", "john@gmail.com")
+		testBody(t, r, correctParams)
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, respXML)
+	})
+
+	address := office
+	address.ID = 0
+	result, err := client.UsersAddressCreate(&address)
+	if err != nil {
+		t.Errorf("UsersAddressCreate returned error: %v", err)
+	}
+	want := &UserAddressResult{Success: true, ID: 1145, Name: "Office"}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("UsersAddressCreate returned %+v, want %+v", result, want)
+	}
+}
+
+func TestUsersAddressUpdate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	respXML := `<?xml version="1.0" encoding="UTF-8"?>
+<ApiResponse xmlns="http://api.namecheap.com/xml.response" Status="OK">
+  <Errors />
+  <RequestedCommand>namecheap.users.address.update</RequestedCommand>
+  <CommandResponse Type="namecheap.users.address.update">
+    <AddressUpdateResult Success="true" AddressId="1145" AddressName="Office" />
+  </CommandResponse>
+  <Server>SERVER-NAME</Server>
+  <GMTTimeDifference>+5</GMTTimeDifference>
+  <ExecutionTime>0.024</ExecutionTime>
+</ApiResponse>`
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("ParseForm returned error: %v", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if got := r.PostForm.Get("Command"); got != "namecheap.users.address.update" {
+			t.Errorf("Command = %q, want namecheap.users.address.update", got)
+		}
+		if got := r.PostForm.Get("AddressId"); got != "1145" {
+			t.Errorf("AddressId = %q, want 1145", got)
+		}
+		if got := r.PostForm.Get("City"); got != "Los Angeles" {
+			t.Errorf("City = %q, want Los Angeles", got)
+		}
+		fmt.Fprint(w, respXML)
+	})
+
+	address := office
+	address.City = "Los Angeles"
+	result, err := client.UsersAddressUpdate(&address)
+	if err != nil {
+		t.Errorf("UsersAddressUpdate returned error: %v", err)
+	}
+	want := &UserAddressResult{Success: true, ID: 1145, Name: "Office"}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("UsersAddressUpdate returned %+v, want %+v", result, want)
+	}
+}
+
+func TestUsersAddressGetInfo(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		correctParams := fillDefaultParams(url.Values{})
+		correctParams.Set("Command", "namecheap.users.address.getInfo")
+		correctParams.Set("AddressId", "1145")
+		testBody(t, r, correctParams)
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, addressGetInfoXML)
+	})
+
+	address, err := client.UsersAddressGetInfo(1145)
+	if err != nil {
+		t.Errorf("UsersAddressGetInfo returned error: %v", err)
+	}
+	if !reflect.DeepEqual(address, &office) {
+		t.Errorf("UsersAddressGetInfo returned %+v, want %+v", address, &office)
+	}
+}
+
+func TestUsersAddressGetList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		correctParams := fillDefaultParams(url.Values{})
+		correctParams.Set("Command", "namecheap.users.address.getList")
+		testBody(t, r, correctParams)
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, addressGetListXML)
+	})
+
+	addresses, err := client.UsersAddressGetList()
+	if err != nil {
+		t.Errorf("UsersAddressGetList returned error: %v", err)
+	}
+	want := []UserAddressListEntry{
+		{ID: 0, Name: "Primary Address", IsDefault: false},
+		{ID: 1145, Name: "Office", IsDefault: true},
+	}
+	if !reflect.DeepEqual(addresses, want) {
+		t.Errorf("UsersAddressGetList returned %+v, want %+v", addresses, want)
+	}
+}
+
+func TestUsersAddressSetDefault(t *testing.T) {
+	setup()
+	defer teardown()
+
+	respXML := `<?xml version="1.0" encoding="UTF-8"?>
+<ApiResponse xmlns="http://api.namecheap.com/xml.response" Status="OK">
+  <Errors />
+  <RequestedCommand>namecheap.users.address.setDefault</RequestedCommand>
+  <CommandResponse Type="namecheap.users.address.setDefault">
+    <AddressSetDefaultResult Success="true" AddressId="1145" />
+  </CommandResponse>
+  <Server>SERVER-NAME</Server>
+  <GMTTimeDifference>+5</GMTTimeDifference>
+  <ExecutionTime>0.024</ExecutionTime>
+</ApiResponse>`
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		correctParams := fillDefaultParams(url.Values{})
+		correctParams.Set("Command", "namecheap.users.address.setDefault")
+		correctParams.Set("AddressId", "1145")
+		testBody(t, r, correctParams)
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, respXML)
+	})
+
+	result, err := client.UsersAddressSetDefault(1145)
+	if err != nil {
+		t.Errorf("UsersAddressSetDefault returned error: %v", err)
+	}
+	want := &UserAddressResult{Success: true, ID: 1145}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("UsersAddressSetDefault returned %+v, want %+v", result, want)
+	}
+}
+
+func TestUsersAddressDelete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	respXML := `<?xml version="1.0" encoding="UTF-8"?>
+<ApiResponse xmlns="http://api.namecheap.com/xml.response" Status="OK">
+  <Errors />
+  <RequestedCommand>namecheap.users.address.delete</RequestedCommand>
+  <CommandResponse Type="namecheap.users.address.delete">
+    <AddressDeleteResult Success="true" ProfileId="1145" UserName="anUser" />
+  </CommandResponse>
+  <Server>SERVER-NAME</Server>
+  <GMTTimeDifference>+5</GMTTimeDifference>
+  <ExecutionTime>0.024</ExecutionTime>
+</ApiResponse>`
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		correctParams := fillDefaultParams(url.Values{})
+		correctParams.Set("Command", "namecheap.users.address.delete")
+		correctParams.Set("AddressId", "1145")
+		testBody(t, r, correctParams)
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, respXML)
+	})
+
+	result, err := client.UsersAddressDelete(1145)
+	if err != nil {
+		t.Errorf("UsersAddressDelete returned error: %v", err)
+	}
+	want := &UserAddressDeleteResult{Success: true, ProfileID: 1145, UserName: "anUser"}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("UsersAddressDelete returned %+v, want %+v", result, want)
+	}
+}
+
+func TestNewRegistrantFromDefaultAddress(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("ParseForm returned error: %v", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		switch r.PostForm.Get("Command") {
+		case "namecheap.users.address.getList":
+			fmt.Fprint(w, addressGetListXML)
+		case "namecheap.users.address.getInfo":
+			if got := r.PostForm.Get("AddressId"); got != "1145" {
+				t.Errorf("AddressId = %q, want the default address 1145", got)
+			}
+			fmt.Fprint(w, addressGetInfoXML)
+		default:
+			t.Errorf("unexpected command %q", r.PostForm.Get("Command"))
+		}
+	})
+
+	if err := client.NewRegistrantFromDefaultAddress(); err != nil {
+		t.Fatalf("NewRegistrantFromDefaultAddress returned error: %v", err)
+	}
+
+	want := newRegistrant(
+		"John", "Smith",
+		"8939 S.cross Blvd", "",
+		"CA", "CA", "90045", "US",
+		"+1.6613102107", "john@gmail.com",
+	)
+	if !reflect.DeepEqual(client.Registrant, want) {
+		t.Errorf("NewRegistrantFromDefaultAddress set %+v, want %+v", client.Registrant, want)
+	}
+}
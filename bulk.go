@@ -0,0 +1,80 @@
+package namecheap
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// defaultBulkWorkers is the number of concurrent requests DomainsGetInfo
+// makes unless told otherwise.
+const defaultBulkWorkers = 4
+
+// DomainsGetInfoOption holds the optional parameters of DomainsGetInfo.
+type DomainsGetInfoOption struct {
+	// Workers is the number of requests in flight at once. Defaults to 4.
+	Workers int
+}
+
+// DomainInfoResult is the outcome of fetching one domain in DomainsGetInfo.
+// Exactly one of Info and Err is set; a response without domain info is
+// reported as an error.
+type DomainInfoResult struct {
+	DomainName string
+	Info       *DomainInfo
+	Err        error
+}
+
+// DomainsGetInfo calls DomainGetInfo for each domain using a pool of workers
+// and returns one result per domain, in the order given. A failed domain does
+// not stop the others. Requests go through the client's RateLimiter and
+// RetryPolicy like any other, so a blocking limiter paces the workers.
+func (client *Client) DomainsGetInfo(domainNames []string, options ...DomainsGetInfoOption) []DomainInfoResult {
+	results, _ := client.DomainsGetInfoContext(context.Background(), domainNames, options...)
+	return results
+}
+
+// DomainsGetInfoContext is like DomainsGetInfo but uses ctx for the underlying
+// requests. Once ctx is done no new requests are started; the domains not yet
+// fetched get ctx's error and it is also returned.
+func (client *Client) DomainsGetInfoContext(ctx context.Context, domainNames []string, options ...DomainsGetInfoOption) ([]DomainInfoResult, error) {
+	workers := defaultBulkWorkers
+	for _, opt := range options {
+		if opt.Workers > 0 {
+			workers = opt.Workers
+		}
+	}
+	if workers > len(domainNames) {
+		workers = len(domainNames)
+	}
+
+	results := make([]DomainInfoResult, len(domainNames))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				result := DomainInfoResult{DomainName: domainNames[i]}
+				if err := ctx.Err(); err != nil {
+					result.Err = err
+				} else {
+					result.Info, result.Err = client.DomainGetInfoContext(ctx, domainNames[i])
+					if result.Info == nil && result.Err == nil {
+						result.Err = fmt.Errorf("namecheap: no domain info returned for %s", domainNames[i])
+					}
+				}
+				results[i] = result
+			}
+		}()
+	}
+
+	for i := range domainNames {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results, ctx.Err()
+}
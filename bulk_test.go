@@ -0,0 +1,157 @@
+package namecheap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// slowDomainInfo answers domains.getInfo after a delay, failing every domain
+// whose name starts with "bad", and records the peak number of requests in
+// flight.
+type slowDomainInfo struct {
+	delay    time.Duration
+	errorXML string
+	inFlight int32
+	peak     int32
+	requests int32
+}
+
+func (s *slowDomainInfo) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	n := atomic.AddInt32(&s.inFlight, 1)
+	defer atomic.AddInt32(&s.inFlight, -1)
+	atomic.AddInt32(&s.requests, 1)
+	for {
+		peak := atomic.LoadInt32(&s.peak)
+		if n <= peak || atomic.CompareAndSwapInt32(&s.peak, peak, n) {
+			break
+		}
+	}
+	time.Sleep(s.delay)
+
+	name := r.FormValue("DomainName")
+	if strings.HasPrefix(name, "bad") {
+		fmt.Fprint(w, s.errorXML)
+		return
+	}
+	fmt.Fprintf(w, `<?xml version="1.0" encoding="utf-8"?>
+<ApiResponse Status="OK" xmlns="http://api.namecheap.com/xml.response">
+  <CommandResponse Type="namecheap.domains.getInfo">
+    <DomainGetInfoResult Status="Ok" ID="1" DomainName="%s" OwnerName="anUser" IsOwner="true" />
+  </CommandResponse>
+</ApiResponse>`, name)
+}
+
+func bulkDomainNames() []string {
+	return []string{
+		"one.com", "bad1.com", "two.com", "three.com", "bad2.com",
+		"four.com", "five.com", "six.com", "seven.com", "eight.com",
+	}
+}
+
+func TestDomainsGetInfo(t *testing.T) {
+	setup()
+	defer teardown()
+
+	server := &slowDomainInfo{delay: 20 * time.Millisecond, errorXML: readFixture(t, "domains.getInfo.error.xml")}
+	mux.Handle("/", server)
+
+	names := bulkDomainNames()
+	results := client.DomainsGetInfo(names, DomainsGetInfoOption{Workers: 3})
+
+	if len(results) != len(names) {
+		t.Fatalf("DomainsGetInfo returned %d results, want %d", len(results), len(names))
+	}
+	for i, result := range results {
+		if result.DomainName != names[i] {
+			t.Errorf("result %d is for %q, want %q", i, result.DomainName, names[i])
+		}
+		if strings.HasPrefix(names[i], "bad") {
+			var apiErrs ApiErrors
+			if !errors.As(result.Err, &apiErrs) || result.Info != nil {
+				t.Errorf("result for %s = %+v, want an API error", names[i], result)
+			}
+			continue
+		}
+		if result.Err != nil || result.Info == nil || result.Info.Name != names[i] {
+			t.Errorf("result for %s = %+v, want its info", names[i], result)
+		}
+	}
+	if peak := atomic.LoadInt32(&server.peak); peak > 3 || peak < 2 {
+		t.Errorf("peak concurrency was %d, want up to 3 workers in parallel", peak)
+	}
+}
+
+func TestDomainsGetInfoEmptyResponse(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8"?>
+<ApiResponse xmlns="http://api.namecheap.com/xml.response" Status="OK">
+  <Errors />
+  <RequestedCommand>namecheap.domains.getInfo</RequestedCommand>
+</ApiResponse>`)
+	})
+
+	results := client.DomainsGetInfo([]string{"domain1.com"})
+	if len(results) != 1 || results[0].Info != nil || results[0].Err == nil {
+		t.Errorf("DomainsGetInfo of a response without info returned %+v, want an error", results)
+	}
+}
+
+func TestDomainsGetInfoRateLimited(t *testing.T) {
+	setup()
+	defer teardown()
+
+	server := &slowDomainInfo{errorXML: readFixture(t, "domains.getInfo.error.xml")}
+	mux.Handle("/", server)
+	client.RateLimiter = NewRateLimiter(false, RateLimit{Limit: 4, Window: time.Minute})
+
+	results := client.DomainsGetInfo(bulkDomainNames(), DomainsGetInfoOption{Workers: 5})
+
+	limited := 0
+	for _, result := range results {
+		var rateErr *RateLimitError
+		if errors.As(result.Err, &rateErr) {
+			limited++
+		}
+	}
+	if server.requests != 4 || limited != 6 {
+		t.Errorf("sent %d requests with %d rate limited, want 4 sent and 6 limited", server.requests, limited)
+	}
+}
+
+func TestDomainsGetInfoCancel(t *testing.T) {
+	setup()
+	defer teardown()
+
+	server := &slowDomainInfo{delay: 50 * time.Millisecond, errorXML: readFixture(t, "domains.getInfo.error.xml")}
+	mux.Handle("/", server)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 75*time.Millisecond)
+	defer cancel()
+
+	names := bulkDomainNames()
+	results, err := client.DomainsGetInfoContext(ctx, names, DomainsGetInfoOption{Workers: 2})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("DomainsGetInfoContext returned %v, want context.DeadlineExceeded", err)
+	}
+	if len(results) != len(names) {
+		t.Fatalf("DomainsGetInfoContext returned %d results, want %d", len(results), len(names))
+	}
+	if results[0].Err != nil {
+		t.Errorf("first domain failed: %v", results[0].Err)
+	}
+	if last := results[len(results)-1]; !errors.Is(last.Err, context.DeadlineExceeded) {
+		t.Errorf("last domain returned %v, want context.DeadlineExceeded", last.Err)
+	}
+	if n := atomic.LoadInt32(&server.requests); n >= int32(len(names)) {
+		t.Errorf("sent %d requests after cancellation, want fewer than %d", n, len(names))
+	}
+}
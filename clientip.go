@@ -0,0 +1,169 @@
+package namecheap
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultClientIP is sent when no ClientIP is configured. Namecheap requires
+	// the parameter but only uses it for end-user attribution.
+	defaultClientIP = "127.0.0.1"
+	// defaultClientIPLookupURL returns the caller's public IP as plain text.
+	defaultClientIPLookupURL = "https://api.ipify.org"
+	// clientIPLookupTimeout bounds a shared lookup, which outlives the
+	// request that started it.
+	clientIPLookupTimeout = 30 * time.Second
+)
+
+type clientIPContextKey struct{}
+
+// WithClientIP returns a copy of ctx that makes any request issued with it
+// send ip as the ClientIp parameter, overriding Client.ClientIP for that call.
+func WithClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, clientIPContextKey{}, ip)
+}
+
+// ValidateClientIP returns an error unless ip is a valid IPv4 or IPv6 address.
+func ValidateClientIP(ip string) error {
+	if net.ParseIP(ip) == nil {
+		return fmt.Errorf("invalid ClientIp %q: must be an IPv4 or IPv6 address", ip)
+	}
+	return nil
+}
+
+// clientIPCache holds the address found by auto-detection so the lookup
+// endpoint is only asked once per Client. Requests that need the address
+// while a lookup is running wait for that lookup instead of starting their
+// own; the lock is never held across the network call.
+type clientIPCache struct {
+	mu      sync.Mutex
+	ip      string
+	pending *clientIPLookup
+}
+
+// clientIPLookup is a lookup in flight. done is closed once ip and err are set.
+type clientIPLookup struct {
+	done chan struct{}
+	ip   string
+	err  error
+}
+
+// ipCacheInit guards the lazy creation of Client.ipCache, for clients that
+// were not made with NewClient.
+var ipCacheInit sync.Mutex
+
+func (client *Client) clientIPCache() *clientIPCache {
+	ipCacheInit.Lock()
+	defer ipCacheInit.Unlock()
+	if client.ipCache == nil {
+		client.ipCache = &clientIPCache{}
+	}
+	return client.ipCache
+}
+
+// clientIP picks the ClientIp for a request: a per-call override from ctx,
+// then Client.ClientIP, then the auto-detected address, then 127.0.0.1.
+func (client *Client) clientIP(ctx context.Context) (string, error) {
+	ip, _ := ctx.Value(clientIPContextKey{}).(string)
+	if ip == "" {
+		ip = client.ClientIP
+	}
+	if ip == "" && client.AutoDetectClientIP {
+		detected, err := client.detectClientIP(ctx)
+		if err != nil {
+			return "", err
+		}
+		ip = detected
+	}
+	if ip == "" {
+		return defaultClientIP, nil
+	}
+	if err := ValidateClientIP(ip); err != nil {
+		return "", err
+	}
+	return ip, nil
+}
+
+// detectClientIP asks ClientIPLookupURL for the public address of this host.
+// The lookup is shared by every request that needs the address while it
+// runs, so it does not use any one request's context: it runs in the
+// background, with the values of the ctx that started it but with its own
+// timeout, and each caller only waits for it as long as its own ctx allows.
+// Only successful lookups are cached, so a failed lookup is retried on the
+// next request.
+func (client *Client) detectClientIP(ctx context.Context) (string, error) {
+	cache := client.clientIPCache()
+
+	cache.mu.Lock()
+	if ip := cache.ip; ip != "" {
+		cache.mu.Unlock()
+		return ip, nil
+	}
+	lookup := cache.pending
+	if lookup == nil {
+		lookup = &clientIPLookup{done: make(chan struct{})}
+		cache.pending = lookup
+		go client.runClientIPLookup(context.WithoutCancel(ctx), cache, lookup)
+	}
+	cache.mu.Unlock()
+
+	select {
+	case <-lookup.done:
+		return lookup.ip, lookup.err
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// runClientIPLookup performs lookup and publishes its result to cache.
+func (client *Client) runClientIPLookup(ctx context.Context, cache *clientIPCache, lookup *clientIPLookup) {
+	ctx, cancel := context.WithTimeout(ctx, clientIPLookupTimeout)
+	defer cancel()
+	lookup.ip, lookup.err = client.lookupClientIP(ctx)
+
+	cache.mu.Lock()
+	if lookup.err == nil {
+		cache.ip = lookup.ip
+	}
+	cache.pending = nil
+	cache.mu.Unlock()
+	close(lookup.done)
+}
+
+func (client *Client) lookupClientIP(ctx context.Context) (string, error) {
+	lookupURL := client.ClientIPLookupURL
+	if lookupURL == "" {
+		lookupURL = defaultClientIPLookupURL
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", lookupURL, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := client.HttpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("ClientIp lookup at %s returned status %d", lookupURL, resp.StatusCode)
+	}
+	buf, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	ip := strings.TrimSpace(string(buf))
+	if err := ValidateClientIP(ip); err != nil {
+		return "", fmt.Errorf("ClientIp lookup at %s: %v", lookupURL, err)
+	}
+	return ip, nil
+}
@@ -0,0 +1,214 @@
+package namecheap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const clientIPRespXML = `<?xml version="1.0" encoding="UTF-8"?>
+<ApiResponse xmlns="http://api.namecheap.com/xml.response" Status="OK">
+  <Errors />
+  <RequestedCommand>namecheap.domains.getInfo</RequestedCommand>
+  <CommandResponse Type="namecheap.domains.getInfo">
+    <DomainGetInfoResult ID="1" DomainName="example.com" />
+  </CommandResponse>
+</ApiResponse>`
+
+func TestClientIP(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := "203.0.113.7"
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		correctParams := fillDefaultParams(url.Values{})
+		correctParams.Set("Command", "namecheap.domains.getInfo")
+		correctParams.Set("DomainName", "example.com")
+		correctParams.Set("ClientIp", want)
+		testBody(t, r, correctParams)
+		fmt.Fprint(w, clientIPRespXML)
+	})
+
+	client.ClientIP = "203.0.113.7"
+	if _, err := client.DomainGetInfo("example.com"); err != nil {
+		t.Errorf("DomainGetInfo returned error: %v", err)
+	}
+
+	want = "2001:db8::1"
+	ctx := WithClientIP(context.Background(), "2001:db8::1")
+	if _, err := client.DomainGetInfoContext(ctx, "example.com"); err != nil {
+		t.Errorf("DomainGetInfoContext returned error: %v", err)
+	}
+}
+
+func TestClientIPInvalid(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request with an invalid ClientIp reached the server")
+	})
+
+	client.ClientIP = "not-an-ip"
+	if _, err := client.DomainGetInfo("example.com"); err == nil {
+		t.Error("DomainGetInfo with invalid ClientIP returned no error")
+	}
+
+	client.ClientIP = ""
+	ctx := WithClientIP(context.Background(), "300.1.1.1")
+	if _, err := client.DomainGetInfoContext(ctx, "example.com"); err == nil {
+		t.Error("DomainGetInfoContext with invalid ClientIp override returned no error")
+	}
+}
+
+func TestClientIPAutoDetect(t *testing.T) {
+	setup()
+	defer teardown()
+
+	lookups := 0
+	mux.HandleFunc("/ip", func(w http.ResponseWriter, r *http.Request) {
+		lookups++
+		fmt.Fprint(w, "198.51.100.23\n")
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		correctParams := fillDefaultParams(url.Values{})
+		correctParams.Set("Command", "namecheap.domains.getInfo")
+		correctParams.Set("DomainName", "example.com")
+		correctParams.Set("ClientIp", "198.51.100.23")
+		testBody(t, r, correctParams)
+		fmt.Fprint(w, clientIPRespXML)
+	})
+
+	client.AutoDetectClientIP = true
+	client.ClientIPLookupURL = server.URL + "/ip"
+	for i := 0; i < 3; i++ {
+		if _, err := client.DomainGetInfo("example.com"); err != nil {
+			t.Errorf("DomainGetInfo returned error: %v", err)
+		}
+	}
+	if lookups != 1 {
+		t.Errorf("ClientIp lookup endpoint was called %d times, want 1", lookups)
+	}
+}
+
+func TestClientIPAutoDetectConcurrent(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var lookups int32
+	release := make(chan struct{})
+	mux.HandleFunc("/ip", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&lookups, 1)
+		<-release
+		fmt.Fprint(w, "198.51.100.23\n")
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, clientIPRespXML)
+	})
+
+	// A client built without NewClient still caches the detected address.
+	literal := &Client{
+		ApiUser:            "anApiUser",
+		ApiToken:           "anToken",
+		UserName:           "anUser",
+		HttpClient:         http.DefaultClient,
+		BaseURL:            server.URL + "/",
+		AutoDetectClientIP: true,
+		ClientIPLookupURL:  server.URL + "/ip",
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := literal.DomainGetInfo("example.com"); err != nil {
+				t.Errorf("DomainGetInfo returned error: %v", err)
+			}
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if _, err := literal.DomainGetInfo("example.com"); err != nil {
+		t.Errorf("DomainGetInfo returned error: %v", err)
+	}
+	if n := atomic.LoadInt32(&lookups); n != 1 {
+		t.Errorf("ClientIp lookup endpoint was called %d times, want 1", n)
+	}
+}
+
+func TestClientIPAutoDetectWaitCanceled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	release := make(chan struct{})
+	defer close(release)
+	mux.HandleFunc("/ip", func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		fmt.Fprint(w, "198.51.100.23\n")
+	})
+
+	client.AutoDetectClientIP = true
+	client.ClientIPLookupURL = server.URL + "/ip"
+	go client.DomainGetInfo("example.com")
+	time.Sleep(20 * time.Millisecond)
+
+	// A request waiting on someone else's lookup gives up with its context.
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := client.DomainGetInfoContext(ctx, "example.com"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("DomainGetInfoContext returned error %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestClientIPAutoDetectFirstCallerCanceled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	mux.HandleFunc("/ip", func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		fmt.Fprint(w, "198.51.100.23\n")
+	})
+
+	client.AutoDetectClientIP = true
+	client.ClientIPLookupURL = server.URL + "/ip"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error, 1)
+	go func() {
+		_, err := client.clientIP(ctx)
+		first <- err
+	}()
+	<-started
+
+	type result struct {
+		ip  string
+		err error
+	}
+	second := make(chan result, 1)
+	go func() {
+		ip, err := client.clientIP(context.Background())
+		second <- result{ip, err}
+	}()
+
+	// Canceling the request that started the lookup only ends its own wait.
+	cancel()
+	if err := <-first; !errors.Is(err, context.Canceled) {
+		t.Errorf("canceled clientIP returned error %v, want %v", err, context.Canceled)
+	}
+	close(release)
+	if got := <-second; got.err != nil || got.ip != "198.51.100.23" {
+		t.Errorf("waiting clientIP returned %q, %v, want 198.51.100.23", got.ip, got.err)
+	}
+}
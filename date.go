@@ -0,0 +1,131 @@
+package namecheap
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// dateLayouts are the formats Namecheap uses for dates, tried in order. Most
+// commands return MM/DD/YYYY, some with a 12-hour time of day appended; the
+// ISO forms are accepted for values that went through JSON.
+var dateLayouts = []string{
+	"1/2/2006",
+	"1/2/2006 3:04:05 PM",
+	"1/2/2006 15:04:05",
+	"2006-01-02",
+	"2006-01-02T15:04:05",
+	time.RFC3339Nano,
+}
+
+// Date is a date, and possibly a time of day, returned by the Namecheap API.
+// It embeds the parsed time, in UTC since the API does not say which zone it
+// uses, and keeps the text it was parsed from, which String returns. Text the
+// API sends in a format Date does not know is kept with the zero time rather
+// than failing the whole response; Unparsed reports it.
+type Date struct {
+	time.Time
+	raw string
+}
+
+// ParseDate parses a date in any of the formats Namecheap returns. An empty
+// string gives the zero Date.
+func ParseDate(s string) (Date, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Date{}, nil
+	}
+	for _, layout := range dateLayouts {
+		if t, err := time.ParseInLocation(layout, s, time.UTC); err == nil {
+			return Date{Time: t, raw: s}, nil
+		}
+	}
+	return Date{}, fmt.Errorf("unrecognised date %q", s)
+}
+
+// String returns the date as the API sent it, or formatted as MM/DD/YYYY for
+// a Date that was not parsed from the API. The zero Date gives "".
+func (d Date) String() string {
+	switch {
+	case d.raw != "":
+		return d.raw
+	case d.IsZero():
+		return ""
+	case d.Hour() == 0 && d.Minute() == 0 && d.Second() == 0:
+		return d.Format("01/02/2006")
+	}
+	return d.Format("01/02/2006 3:04:05 PM")
+}
+
+// DaysUntil returns the number of calendar days from the day of t to the day
+// of d, negative once d has passed.
+func (d Date) DaysUntil(t time.Time) int {
+	return int(startOfDay(d.Time).Sub(startOfDay(t)).Hours() / 24)
+}
+
+// Unparsed reports whether d holds text from the API that is not in a format
+// Date knows, leaving its time zero.
+func (d Date) Unparsed() bool {
+	return d.IsZero() && d.raw != ""
+}
+
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// UnmarshalXMLAttr never fails on the date itself: text in a format it does
+// not know is kept, so String still returns it, with the zero time.
+func (d *Date) UnmarshalXMLAttr(attr xml.Attr) error {
+	*d = lenientDate(attr.Value)
+	return nil
+}
+
+// UnmarshalXML is lenient like UnmarshalXMLAttr.
+func (d *Date) UnmarshalXML(decoder *xml.Decoder, start xml.StartElement) error {
+	var s string
+	if err := decoder.DecodeElement(&s, &start); err != nil {
+		return err
+	}
+	*d = lenientDate(s)
+	return nil
+}
+
+func lenientDate(s string) Date {
+	parsed, err := ParseDate(s)
+	if err != nil {
+		return Date{raw: strings.TrimSpace(s)}
+	}
+	return parsed
+}
+
+// MarshalJSON encodes the date as YYYY-MM-DD, or as RFC 3339 when it has a
+// time of day. The zero Date is encoded as null.
+func (d Date) MarshalJSON() ([]byte, error) {
+	switch {
+	case d.IsZero():
+		return []byte("null"), nil
+	case d.Hour() == 0 && d.Minute() == 0 && d.Second() == 0:
+		return json.Marshal(d.Format("2006-01-02"))
+	}
+	return json.Marshal(d.Format(time.RFC3339))
+}
+
+func (d *Date) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*d = Date{}
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	parsed, err := ParseDate(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
@@ -0,0 +1,180 @@
+package namecheap
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+	"time"
+)
+
+func mustParseDate(t *testing.T, s string) Date {
+	t.Helper()
+	d, err := ParseDate(s)
+	if err != nil {
+		t.Fatalf("ParseDate(%q) returned error: %v", s, err)
+	}
+	return d
+}
+
+func TestParseDate(t *testing.T) {
+	cases := map[string]time.Time{
+		"11/04/2014":             time.Date(2014, 11, 4, 0, 0, 0, 0, time.UTC),
+		"4/3/2021":               time.Date(2021, 4, 3, 0, 0, 0, 0, time.UTC),
+		"4/30/2021 11:31:13 AM":  time.Date(2021, 4, 30, 11, 31, 13, 0, time.UTC),
+		"12/01/2020 1:02:03 PM":  time.Date(2020, 12, 1, 13, 2, 3, 0, time.UTC),
+		"12/01/2020 13:02:03":    time.Date(2020, 12, 1, 13, 2, 3, 0, time.UTC),
+		"2015-11-04":             time.Date(2015, 11, 4, 0, 0, 0, 0, time.UTC),
+		"2015-11-04T10:00:00":    time.Date(2015, 11, 4, 10, 0, 0, 0, time.UTC),
+		"2015-11-04T10:00:00Z":   time.Date(2015, 11, 4, 10, 0, 0, 0, time.UTC),
+		" 11/04/2014 ":           time.Date(2014, 11, 4, 0, 0, 0, 0, time.UTC),
+		"2015-11-04T10:00:00.5Z": time.Date(2015, 11, 4, 10, 0, 0, 5e8, time.UTC),
+	}
+	for in, want := range cases {
+		d, err := ParseDate(in)
+		if err != nil {
+			t.Errorf("ParseDate(%q) returned error: %v", in, err)
+			continue
+		}
+		if !d.Equal(want) {
+			t.Errorf("ParseDate(%q) = %v, want %v", in, d.Time, want)
+		}
+	}
+
+	if d, err := ParseDate(""); err != nil || !d.IsZero() || d.String() != "" {
+		t.Errorf("ParseDate(\"\") = %v, %v, want the zero Date", d, err)
+	}
+	for _, in := range []string{"13/01/2020", "yesterday", "2020/01/02"} {
+		if _, err := ParseDate(in); err == nil {
+			t.Errorf("ParseDate(%q) should have returned an error", in)
+		}
+	}
+}
+
+func TestDateString(t *testing.T) {
+	if got := mustParseDate(t, "4/30/2021 11:31:13 AM").String(); got != "4/30/2021 11:31:13 AM" {
+		t.Errorf("String of a parsed date = %q, want the API text", got)
+	}
+	if got := (Date{Time: time.Date(2021, 4, 3, 0, 0, 0, 0, time.UTC)}).String(); got != "04/03/2021" {
+		t.Errorf("String of a constructed date = %q, want 04/03/2021", got)
+	}
+}
+
+func TestDateXML(t *testing.T) {
+	var v struct {
+		Attr    Date `xml:"Expires,attr"`
+		Element Date `xml:"Details>ExpiredDate"`
+		Empty   Date `xml:"Empty,attr"`
+	}
+	data := `<Result Expires="11/04/2015" Empty=""><Details><ExpiredDate>4/30/2021 11:31:13 AM</ExpiredDate></Details></Result>`
+	if err := xml.Unmarshal([]byte(data), &v); err != nil {
+		t.Fatalf("xml.Unmarshal returned error: %v", err)
+	}
+	if v.Attr.String() != "11/04/2015" || v.Element.Hour() != 11 || !v.Empty.IsZero() {
+		t.Errorf("xml.Unmarshal gave %+v", v)
+	}
+
+	bad := `<Result Expires="not a date"><Details><ExpiredDate>soon</ExpiredDate></Details></Result>`
+	if err := xml.Unmarshal([]byte(bad), &v); err != nil {
+		t.Fatalf("xml.Unmarshal of an unknown date format returned error: %v", err)
+	}
+	if !v.Attr.IsZero() || v.Attr.String() != "not a date" || !v.Element.IsZero() || v.Element.String() != "soon" {
+		t.Errorf("xml.Unmarshal of unknown date formats gave %+v, want zero times keeping the text", v)
+	}
+}
+
+func TestDateJSON(t *testing.T) {
+	v := struct {
+		Expires Date
+		Renewed Date
+		Never   Date
+	}{
+		Expires: mustParseDate(t, "11/04/2015"),
+		Renewed: mustParseDate(t, "4/30/2021 11:31:13 AM"),
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("json.Marshal returned error: %v", err)
+	}
+	want := `{"Expires":"2015-11-04","Renewed":"2021-04-30T11:31:13Z","Never":null}`
+	if string(data) != want {
+		t.Errorf("json.Marshal = %s, want %s", data, want)
+	}
+
+	var back struct {
+		Expires Date
+		Renewed Date
+		Never   Date
+	}
+	if err := json.Unmarshal(data, &back); err != nil {
+		t.Fatalf("json.Unmarshal returned error: %v", err)
+	}
+	if !back.Expires.Equal(v.Expires.Time) || !back.Renewed.Equal(v.Renewed.Time) || !back.Never.IsZero() {
+		t.Errorf("json.Unmarshal gave %+v, want %+v", back, v)
+	}
+}
+
+func TestDaysUntil(t *testing.T) {
+	expires := mustParseDate(t, "11/04/2015")
+	cases := []struct {
+		now  time.Time
+		want int
+	}{
+		{time.Date(2015, 11, 4, 23, 0, 0, 0, time.UTC), 0},
+		{time.Date(2015, 11, 3, 1, 0, 0, 0, time.UTC), 1},
+		{time.Date(2015, 10, 5, 12, 0, 0, 0, time.UTC), 30},
+		{time.Date(2015, 11, 10, 0, 0, 0, 0, time.UTC), -6},
+		{time.Date(2015, 3, 4, 0, 0, 0, 0, time.FixedZone("EST", -5*3600)), 245},
+	}
+	for _, c := range cases {
+		if got := expires.DaysUntil(c.now); got != c.want {
+			t.Errorf("DaysUntil(%v) = %d, want %d", c.now, got, c.want)
+		}
+	}
+
+	domain := DomainGetListResult{Expires: Date{Time: startOfDay(time.Now()).AddDate(0, 0, 10)}}
+	if got := domain.DaysUntilExpiry(); got != 10 {
+		t.Errorf("DaysUntilExpiry = %d, want 10", got)
+	}
+}
+
+func TestDateStringAccessors(t *testing.T) {
+	var list struct {
+		Domain     DomainGetListResult     `xml:"Domain"`
+		Whoisguard WhoisguardGetListResult `xml:"Whoisguard"`
+		Info       DomainInfo              `xml:"DomainGetInfoResult"`
+		Renew      DomainRenewResult       `xml:"DomainRenewResult"`
+	}
+	data := `<Result>
+  <Domain Created="11/04/2014" Expires="someday" />
+  <Whoisguard Created="12/18/2013" Expires="12/18/2014" />
+  <DomainGetInfoResult>
+    <DomainDetails><CreatedDate>11/04/2014</CreatedDate><ExpiredDate>11/04/2015</ExpiredDate></DomainDetails>
+    <Whoisguard><ExpiredDate>11/04/2015</ExpiredDate></Whoisguard>
+  </DomainGetInfoResult>
+  <DomainRenewResult><DomainDetails><ExpiredDate>4/30/2021 11:31:13 AM</ExpiredDate></DomainDetails></DomainRenewResult>
+</Result>`
+	if err := xml.Unmarshal([]byte(data), &list); err != nil {
+		t.Fatalf("xml.Unmarshal returned error: %v", err)
+	}
+	accessors := map[string]struct{ got, want string }{
+		"DomainGetListResult.CreatedString":     {list.Domain.CreatedString(), "11/04/2014"},
+		"DomainGetListResult.ExpiresString":     {list.Domain.ExpiresString(), "someday"},
+		"WhoisguardGetListResult.CreatedString": {list.Whoisguard.CreatedString(), "12/18/2013"},
+		"WhoisguardGetListResult.ExpiresString": {list.Whoisguard.ExpiresString(), "12/18/2014"},
+		"DomainInfo.CreatedString":              {list.Info.CreatedString(), "11/04/2014"},
+		"DomainInfo.ExpiresString":              {list.Info.ExpiresString(), "11/04/2015"},
+		"Whoisguard.ExpiredDateString":          {list.Info.Whoisguard.ExpiredDateString(), "11/04/2015"},
+		"DomainRenewResult.ExpireDateString":    {list.Renew.ExpireDateString(), "4/30/2021 11:31:13 AM"},
+	}
+	for name, a := range accessors {
+		if a.got != a.want {
+			t.Errorf("%s = %q, want %q", name, a.got, a.want)
+		}
+	}
+	if !list.Domain.Expires.Unparsed() || list.Domain.Created.Unparsed() {
+		t.Errorf("Unparsed is wrong for %+v", list.Domain)
+	}
+	if !list.Info.Expires.Equal(time.Date(2015, 11, 4, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("DomainInfo.Expires = %v, want 2015-11-04", list.Info.Expires.Time)
+	}
+}
@@ -1,30 +1,56 @@
 package namecheap
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"strconv"
+	"strings"
 )
 
 const (
-	domainsDNSGetHosts  = "namecheap.domains.dns.getHosts"
-	domainsDNSSetHosts  = "namecheap.domains.dns.setHosts"
-	domainsDNSSetCustom = "namecheap.domains.dns.setCustom"
+	domainsDNSGetHosts   = "namecheap.domains.dns.getHosts"
+	domainsDNSSetHosts   = "namecheap.domains.dns.setHosts"
+	domainsDNSSetCustom  = "namecheap.domains.dns.setCustom"
+	domainsDNSSetDefault = "namecheap.domains.dns.setDefault"
+	domainsDNSGetList    = "namecheap.domains.dns.getList"
+
+	domainsDNSGetEmailForwarding = "namecheap.domains.dns.getEmailForwarding"
+	domainsDNSSetEmailForwarding = "namecheap.domains.dns.setEmailForwarding"
+)
+
+// Namecheap accepts between minCustomNameservers and maxCustomNameservers
+// hostnames in a single setCustom call.
+const (
+	minCustomNameservers = 2
+	maxCustomNameservers = 12
+)
+
+// EmailType selects how mail for a domain is handled when its host records
+// are set.
+type EmailType string
+
+const (
+	EmailMX  EmailType = "MX"  // user-supplied MX records
+	EmailMXE EmailType = "MXE" // MX easy: a single mail server IP address
+	EmailFWD EmailType = "FWD" // Namecheap's free email forwarding
+	EmailOX  EmailType = "OX"  // Namecheap private email
 )
 
 type DomainDNSGetHostsResult struct {
 	Domain        string          `xml:"Domain,attr"`
 	IsUsingOurDNS bool            `xml:"IsUsingOurDNS,attr"`
+	EmailType     EmailType       `xml:"EmailType,attr"`
 	Hosts         []DomainDNSHost `xml:"host"`
 }
 
 type DomainDNSHost struct {
-	ID      int    `xml:"HostId,attr"`
-	Name    string `xml:"Name,attr"`
-	Type    string `xml:"Type,attr"`
-	Address string `xml:"Address,attr"`
-	MXPref  int    `xml:"MXPref,attr"`
-	TTL     int    `xml:"TTL,attr"`
+	ID      int        `xml:"HostId,attr"`
+	Name    string     `xml:"Name,attr"`
+	Type    RecordType `xml:"Type,attr"`
+	Address string     `xml:"Address,attr"`
+	MXPref  int        `xml:"MXPref,attr"`
+	TTL     int        `xml:"TTL,attr"`
 }
 
 type DomainDNSSetHostsResult struct {
@@ -33,6 +59,11 @@ type DomainDNSSetHostsResult struct {
 }
 
 func (client *Client) DomainsDNSGetHosts(sld, tld string) (*DomainDNSGetHostsResult, error) {
+	return client.DomainsDNSGetHostsContext(context.Background(), sld, tld)
+}
+
+// DomainsDNSGetHostsContext is like DomainsDNSGetHosts but uses ctx for the underlying request.
+func (client *Client) DomainsDNSGetHostsContext(ctx context.Context, sld, tld string) (*DomainDNSGetHostsResult, error) {
 	requestInfo := &ApiRequest{
 		command: domainsDNSGetHosts,
 		method:  "POST",
@@ -41,7 +72,7 @@ func (client *Client) DomainsDNSGetHosts(sld, tld string) (*DomainDNSGetHostsRes
 	requestInfo.params.Set("SLD", sld)
 	requestInfo.params.Set("TLD", tld)
 
-	resp, err := client.do(requestInfo)
+	resp, err := client.do(ctx, requestInfo)
 	if err != nil {
 		return nil, err
 	}
@@ -49,9 +80,27 @@ func (client *Client) DomainsDNSGetHosts(sld, tld string) (*DomainDNSGetHostsRes
 	return resp.DomainDNSHosts, nil
 }
 
+// DomainDNSSetHostsOption holds the optional parameters of DomainDNSSetHosts.
+type DomainDNSSetHostsOption struct {
+	// EmailType is sent as-is when set. Otherwise it is derived from the
+	// hosts: MX when any MX record is present, MXE for an MXE record.
+	EmailType EmailType
+}
+
 func (client *Client) DomainDNSSetHosts(
-	sld, tld string, hosts []DomainDNSHost,
+	sld, tld string, hosts []DomainDNSHost, options ...DomainDNSSetHostsOption,
 ) (*DomainDNSSetHostsResult, error) {
+	return client.DomainDNSSetHostsContext(context.Background(), sld, tld, hosts, options...)
+}
+
+// DomainDNSSetHostsContext is like DomainDNSSetHosts but uses ctx for the underlying request.
+func (client *Client) DomainDNSSetHostsContext(
+	ctx context.Context, sld, tld string, hosts []DomainDNSHost, options ...DomainDNSSetHostsOption,
+) (*DomainDNSSetHostsResult, error) {
+	if err := ValidateHosts(hosts); err != nil {
+		return nil, err
+	}
+
 	requestInfo := &ApiRequest{
 		command: domainsDNSSetHosts,
 		method:  "POST",
@@ -60,18 +109,33 @@ func (client *Client) DomainDNSSetHosts(
 	requestInfo.params.Set("SLD", sld)
 	requestInfo.params.Set("TLD", tld)
 
+	var emailType EmailType
 	for i, h := range hosts {
+		recordType := RecordType(strings.ToUpper(string(h.Type)))
 		requestInfo.params.Set(fmt.Sprintf("HostName%v", i+1), h.Name)
-		requestInfo.params.Set(fmt.Sprintf("RecordType%v", i+1), h.Type)
+		requestInfo.params.Set(fmt.Sprintf("RecordType%v", i+1), string(recordType))
 		requestInfo.params.Set(fmt.Sprintf("Address%v", i+1), h.Address)
-		if h.Type == "MX" {
+		switch recordType {
+		case RecordMX:
 			requestInfo.params.Set(fmt.Sprintf("MXPref%v", i+1), strconv.Itoa(h.MXPref))
-			requestInfo.params.Set("EmailType", "MX")
+			emailType = EmailMX
+		case RecordMXE:
+			emailType = EmailMXE
+		}
+		if h.TTL != 0 {
+			requestInfo.params.Set(fmt.Sprintf("TTL%v", i+1), strconv.Itoa(h.TTL))
 		}
-		requestInfo.params.Set(fmt.Sprintf("TTL%v", i+1), strconv.Itoa(h.TTL))
+	}
+	for _, opt := range options {
+		if opt.EmailType != "" {
+			emailType = opt.EmailType
+		}
+	}
+	if emailType != "" {
+		requestInfo.params.Set("EmailType", string(emailType))
 	}
 
-	resp, err := client.do(requestInfo)
+	resp, err := client.do(ctx, requestInfo)
 	if err != nil {
 		return nil, err
 	}
@@ -83,7 +147,23 @@ type DomainDNSSetCustomResult struct {
 	Update bool   `xml:"Update,attr"`
 }
 
-func (client *Client) DomainDNSSetCustom(sld, tld, nameservers string) (*DomainDNSSetCustomResult, error) {
+// DomainDNSSetCustom points the domain at the given nameserver hostnames,
+// of which there must be at least 2 and at most 12.
+func (client *Client) DomainDNSSetCustom(sld, tld string, nameservers []string) (*DomainDNSSetCustomResult, error) {
+	return client.DomainDNSSetCustomContext(context.Background(), sld, tld, nameservers)
+}
+
+// DomainDNSSetCustomContext is like DomainDNSSetCustom but uses ctx for the underlying request.
+func (client *Client) DomainDNSSetCustomContext(ctx context.Context, sld, tld string, nameservers []string) (*DomainDNSSetCustomResult, error) {
+	if n := len(nameservers); n < minCustomNameservers || n > maxCustomNameservers {
+		return nil, fmt.Errorf("got %d nameservers, must be between %d and %d", n, minCustomNameservers, maxCustomNameservers)
+	}
+	for _, ns := range nameservers {
+		if !validNameserverHostname(ns) {
+			return nil, fmt.Errorf("invalid nameserver hostname %q", ns)
+		}
+	}
+
 	requestInfo := &ApiRequest{
 		command: domainsDNSSetCustom,
 		method:  "POST",
@@ -91,11 +171,151 @@ func (client *Client) DomainDNSSetCustom(sld, tld, nameservers string) (*DomainD
 	}
 	requestInfo.params.Set("SLD", sld)
 	requestInfo.params.Set("TLD", tld)
-	requestInfo.params.Set("Nameservers", nameservers)
+	requestInfo.params.Set("Nameservers", strings.Join(nameservers, ","))
 
-	resp, err := client.do(requestInfo)
+	resp, err := client.do(ctx, requestInfo)
 	if err != nil {
 		return nil, err
 	}
 	return resp.DomainDNSSetCustom, nil
 }
+
+// validNameserverHostname reports whether name is a dotted hostname whose
+// labels all pass ValidDomainName. Nameservers are hosts, so unlike record
+// targets (see validRecordTarget) their names cannot hold underscores.
+func validNameserverHostname(name string) bool {
+	labels := strings.Split(strings.TrimSuffix(name, "."), ".")
+	if len(labels) < 2 {
+		return false
+	}
+	for _, label := range labels {
+		if label == "" || !ValidDomainName(label) {
+			return false
+		}
+	}
+	return true
+}
+
+type DomainDNSSetDefaultResult struct {
+	Domain  string `xml:"Domain,attr"`
+	Updated bool   `xml:"Updated,attr"`
+}
+
+// DomainDNSSetDefault switches the domain back to Namecheap's default DNS
+// servers.
+func (client *Client) DomainDNSSetDefault(sld, tld string) (*DomainDNSSetDefaultResult, error) {
+	return client.DomainDNSSetDefaultContext(context.Background(), sld, tld)
+}
+
+// DomainDNSSetDefaultContext is like DomainDNSSetDefault but uses ctx for the underlying request.
+func (client *Client) DomainDNSSetDefaultContext(ctx context.Context, sld, tld string) (*DomainDNSSetDefaultResult, error) {
+	requestInfo := &ApiRequest{
+		command: domainsDNSSetDefault,
+		method:  "POST",
+		params:  url.Values{},
+	}
+	requestInfo.params.Set("SLD", sld)
+	requestInfo.params.Set("TLD", tld)
+
+	resp, err := client.do(ctx, requestInfo)
+	if err != nil {
+		return nil, err
+	}
+	return resp.DomainDNSSetDefault, nil
+}
+
+type DomainDNSGetListResult struct {
+	Domain        string   `xml:"Domain,attr"`
+	IsUsingOurDNS bool     `xml:"IsUsingOurDNS,attr"`
+	Nameservers   []string `xml:"Nameserver"`
+}
+
+// DomainDNSGetList returns the nameservers the domain is currently using.
+func (client *Client) DomainDNSGetList(sld, tld string) (*DomainDNSGetListResult, error) {
+	return client.DomainDNSGetListContext(context.Background(), sld, tld)
+}
+
+// DomainDNSGetListContext is like DomainDNSGetList but uses ctx for the underlying request.
+func (client *Client) DomainDNSGetListContext(ctx context.Context, sld, tld string) (*DomainDNSGetListResult, error) {
+	requestInfo := &ApiRequest{
+		command: domainsDNSGetList,
+		method:  "POST",
+		params:  url.Values{},
+	}
+	requestInfo.params.Set("SLD", sld)
+	requestInfo.params.Set("TLD", tld)
+
+	resp, err := client.do(ctx, requestInfo)
+	if err != nil {
+		return nil, err
+	}
+	return resp.DomainDNSList, nil
+}
+
+// EmailForward forwards mail sent to Mailbox@domain to the ForwardTo address.
+type EmailForward struct {
+	Mailbox   string `xml:"mailbox,attr"`
+	ForwardTo string `xml:",chardata"`
+}
+
+type DomainDNSEmailForwardingResult struct {
+	Domain   string         `xml:"domain,attr"`
+	Forwards []EmailForward `xml:"Forward"`
+}
+
+type DomainDNSSetEmailForwardResult struct {
+	Domain    string `xml:"Domain,attr"`
+	IsSuccess bool   `xml:"IsSuccess,attr"`
+}
+
+// DomainDNSGetEmailForwarding returns the mailbox forwards of a domain that
+// uses Namecheap's free email forwarding.
+func (client *Client) DomainDNSGetEmailForwarding(domainName string) (*DomainDNSEmailForwardingResult, error) {
+	return client.DomainDNSGetEmailForwardingContext(context.Background(), domainName)
+}
+
+// DomainDNSGetEmailForwardingContext is like DomainDNSGetEmailForwarding but uses ctx for the underlying request.
+func (client *Client) DomainDNSGetEmailForwardingContext(ctx context.Context, domainName string) (*DomainDNSEmailForwardingResult, error) {
+	requestInfo := &ApiRequest{
+		command: domainsDNSGetEmailForwarding,
+		method:  "POST",
+		params:  url.Values{},
+	}
+	requestInfo.params.Set("DomainName", domainName)
+
+	resp, err := client.do(ctx, requestInfo)
+	if err != nil {
+		return nil, err
+	}
+	return resp.DomainEmailForwarding, nil
+}
+
+// DomainDNSSetEmailForwarding replaces the mailbox forwards of a domain with
+// forwards. The domain's EmailType must be FWD for them to take effect.
+func (client *Client) DomainDNSSetEmailForwarding(domainName string, forwards []EmailForward) (*DomainDNSSetEmailForwardResult, error) {
+	return client.DomainDNSSetEmailForwardingContext(context.Background(), domainName, forwards)
+}
+
+// DomainDNSSetEmailForwardingContext is like DomainDNSSetEmailForwarding but uses ctx for the underlying request.
+func (client *Client) DomainDNSSetEmailForwardingContext(ctx context.Context, domainName string, forwards []EmailForward) (*DomainDNSSetEmailForwardResult, error) {
+	requestInfo := &ApiRequest{
+		command: domainsDNSSetEmailForwarding,
+		method:  "POST",
+		params:  url.Values{},
+	}
+	requestInfo.params.Set("DomainName", domainName)
+
+	for i, f := range forwards {
+		if f.Mailbox == "" || f.ForwardTo == "" {
+			return nil, fmt.Errorf("email forward %d: mailbox and forward-to address are required", i+1)
+		}
+		requestInfo.params.Set(fmt.Sprintf("MailBox%v", i+1), f.Mailbox)
+		requestInfo.params.Set(fmt.Sprintf("ForwardTo%v", i+1), f.ForwardTo)
+	}
+
+	resp, err := client.do(ctx, requestInfo)
+	if err != nil {
+		return nil, err
+	}
+	return resp.DomainSetEmailForwarding, nil
+}
@@ -155,7 +155,7 @@ func TestDomainsDNSSetCustom(t *testing.T) {
 		fmt.Fprint(w, respXML)
 	})
 
-	result, err := client.DomainDNSSetCustom("domain", "com", "dns1.name-servers.com,dns2.name-servers.com")
+	result, err := client.DomainDNSSetCustom("domain", "com", []string{"dns1.name-servers.com", "dns2.name-servers.com"})
 	if err != nil {
 		t.Errorf("DomainDNSSetCustom returned error: %v", err)
 	}
@@ -168,4 +168,252 @@ func TestDomainsDNSSetCustom(t *testing.T) {
 	if !reflect.DeepEqual(result, want) {
 		t.Errorf("DomainsDNSSetCustom returned %+v, want %+v", result, want)
 	}
+
+	invalid := [][]string{
+		nil,
+		{"dns1.name-servers.com"},
+		{"dns1.name-servers.com", "dns2.name-servers.com,dns3.name-servers.com"},
+		{"dns1.name-servers.com", "localhost"},
+		{"dns1.name-servers.com", "dns_2.name-servers.com"},
+		make([]string, maxCustomNameservers+1),
+	}
+	for _, nameservers := range invalid {
+		if _, err := client.DomainDNSSetCustom("domain", "com", nameservers); err == nil {
+			t.Errorf("DomainDNSSetCustom(%q) should have returned an error", nameservers)
+		}
+	}
+}
+
+func TestDomainsDNSSetDefault(t *testing.T) {
+	setup()
+	defer teardown()
+
+	respXML := `<?xml version="1.0" encoding="UTF-8"?>
+<ApiResponse xmlns="http://api.namecheap.com/xml.response" Status="OK">
+  <Errors />
+  <RequestedCommand>namecheap.domains.dns.setDefault</RequestedCommand>
+  <CommandResponse Type="namecheap.domains.dns.setDefault">
+    <DomainDNSSetDefaultResult Domain="domain.com" Updated="true" />
+  </CommandResponse>
+  <Server>SERVER-NAME</Server>
+  <GMTTimeDifference>+5</GMTTimeDifference>
+  <ExecutionTime>32.76</ExecutionTime>
+</ApiResponse>`
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		correctParams := fillDefaultParams(url.Values{})
+		correctParams.Set("Command", "namecheap.domains.dns.setDefault")
+		correctParams.Set("SLD", "domain")
+		correctParams.Set("TLD", "com")
+		testBody(t, r, correctParams)
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, respXML)
+	})
+
+	result, err := client.DomainDNSSetDefault("domain", "com")
+	if err != nil {
+		t.Errorf("DomainDNSSetDefault returned error: %v", err)
+	}
+
+	want := &DomainDNSSetDefaultResult{Domain: "domain.com", Updated: true}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("DomainDNSSetDefault returned %+v, want %+v", result, want)
+	}
+}
+
+func TestDomainsDNSGetList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	respXML := `<?xml version="1.0" encoding="UTF-8"?>
+<ApiResponse xmlns="http://api.namecheap.com/xml.response" Status="OK">
+  <Errors />
+  <RequestedCommand>namecheap.domains.dns.getList</RequestedCommand>
+  <CommandResponse Type="namecheap.domains.dns.getList">
+    <DomainDNSGetListResult Domain="domain.com" IsUsingOurDNS="false">
+      <Nameserver>ns1.cloudflare.com</Nameserver>
+      <Nameserver>ns2.cloudflare.com</Nameserver>
+    </DomainDNSGetListResult>
+  </CommandResponse>
+  <Server>SERVER-NAME</Server>
+  <GMTTimeDifference>+5</GMTTimeDifference>
+  <ExecutionTime>32.76</ExecutionTime>
+</ApiResponse>`
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		correctParams := fillDefaultParams(url.Values{})
+		correctParams.Set("Command", "namecheap.domains.dns.getList")
+		correctParams.Set("SLD", "domain")
+		correctParams.Set("TLD", "com")
+		testBody(t, r, correctParams)
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, respXML)
+	})
+
+	result, err := client.DomainDNSGetList("domain", "com")
+	if err != nil {
+		t.Errorf("DomainDNSGetList returned error: %v", err)
+	}
+
+	want := &DomainDNSGetListResult{
+		Domain:        "domain.com",
+		IsUsingOurDNS: false,
+		Nameservers:   []string{"ns1.cloudflare.com", "ns2.cloudflare.com"},
+	}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("DomainDNSGetList returned %+v, want %+v", result, want)
+	}
+}
+
+func TestDomainsDNSSetHostsEmailType(t *testing.T) {
+	setup()
+	defer teardown()
+
+	respXML := `<?xml version="1.0" encoding="UTF-8"?>
+<ApiResponse xmlns="http://api.namecheap.com/xml.response" Status="OK">
+  <Errors />
+  <RequestedCommand>namecheap.domains.dns.setHosts</RequestedCommand>
+  <CommandResponse Type="namecheap.domains.dns.setHosts">
+    <DomainDNSSetHostsResult Domain="domain51.com" IsSuccess="true" />
+  </CommandResponse>
+  <Server>SERVER-NAME</Server>
+  <GMTTimeDifference>+5</GMTTimeDifference>
+  <ExecutionTime>32.76</ExecutionTime>
+</ApiResponse>`
+
+	hosts := []DomainDNSHost{
+		{Name: "@", Type: "A", Address: "1.2.3.4", TTL: 1800},
+		{Name: "@", Type: "MX", Address: "mx.example.com", MXPref: 10, TTL: 1800},
+	}
+
+	cases := []struct {
+		name    string
+		hosts   []DomainDNSHost
+		options []DomainDNSSetHostsOption
+		want    string
+	}{
+		{"derived from MX record", hosts, nil, "MX"},
+		{"derived from lowercase mx record", []DomainDNSHost{{Name: "@", Type: "mx", Address: "mx.example.com", MXPref: 10}}, nil, "MX"},
+		{"no mail records", hosts[:1], nil, ""},
+		{"explicit forwarding", hosts[:1], []DomainDNSSetHostsOption{{EmailType: EmailFWD}}, "FWD"},
+		{"explicit overrides MX", hosts, []DomainDNSSetHostsOption{{EmailType: EmailOX}}, "OX"},
+	}
+
+	var got string
+	var sent bool
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("ParseForm returned error: %v", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		_, sent = r.PostForm["EmailType"]
+		got = r.PostForm.Get("EmailType")
+		fmt.Fprint(w, respXML)
+	})
+
+	for _, c := range cases {
+		if _, err := client.DomainDNSSetHosts("domain51", "com", c.hosts, c.options...); err != nil {
+			t.Fatalf("%s: DomainDNSSetHosts returned error: %v", c.name, err)
+		}
+		if got != c.want {
+			t.Errorf("%s: EmailType = %q, want %q", c.name, got, c.want)
+		}
+		if c.want == "" && sent {
+			t.Errorf("%s: EmailType should not be sent", c.name)
+		}
+	}
+}
+
+func TestDomainDNSGetEmailForwarding(t *testing.T) {
+	setup()
+	defer teardown()
+
+	respXML := `<?xml version="1.0" encoding="UTF-8"?>
+<ApiResponse xmlns="http://api.namecheap.com/xml.response" Status="OK">
+  <Errors />
+  <RequestedCommand>namecheap.domains.dns.getEmailForwarding</RequestedCommand>
+  <CommandResponse Type="namecheap.domains.dns.getEmailForwarding">
+    <DomainEmailForwarding domain="domain.com">
+      <Forward mailbox="info">info@gmail.com</Forward>
+      <Forward mailbox="sales">sales@gmail.com</Forward>
+    </DomainEmailForwarding>
+  </CommandResponse>
+  <Server>SERVER-NAME</Server>
+  <GMTTimeDifference>+5</GMTTimeDifference>
+  <ExecutionTime>0.024</ExecutionTime>
+</ApiResponse>`
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		correctParams := fillDefaultParams(url.Values{})
+		correctParams.Set("Command", "namecheap.domains.dns.getEmailForwarding")
+		correctParams.Set("DomainName", "domain.com")
+		testBody(t, r, correctParams)
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, respXML)
+	})
+
+	result, err := client.DomainDNSGetEmailForwarding("domain.com")
+	if err != nil {
+		t.Errorf("DomainDNSGetEmailForwarding returned error: %v", err)
+	}
+
+	want := &DomainDNSEmailForwardingResult{
+		Domain: "domain.com",
+		Forwards: []EmailForward{
+			{Mailbox: "info", ForwardTo: "info@gmail.com"},
+			{Mailbox: "sales", ForwardTo: "sales@gmail.com"},
+		},
+	}
+
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("DomainDNSGetEmailForwarding returned %+v, want %+v", result, want)
+	}
+}
+
+func TestDomainDNSSetEmailForwarding(t *testing.T) {
+	setup()
+	defer teardown()
+
+	respXML := `<?xml version="1.0" encoding="UTF-8"?>
+<ApiResponse xmlns="http://api.namecheap.com/xml.response" Status="OK">
+  <Errors />
+  <RequestedCommand>namecheap.domains.dns.setEmailForwarding</RequestedCommand>
+  <CommandResponse Type="namecheap.domains.dns.setEmailForwarding">
+    <DomainEmailForwardingResult Domain="domain.com" IsSuccess="true" />
+  </CommandResponse>
+  <Server>SERVER-NAME</Server>
+  <GMTTimeDifference>+5</GMTTimeDifference>
+  <ExecutionTime>0.024</ExecutionTime>
+</ApiResponse>`
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		correctParams := fillDefaultParams(url.Values{})
+		correctParams.Set("Command", "namecheap.domains.dns.setEmailForwarding")
+		correctParams.Set("DomainName", "domain.com")
+		correctParams.Set("MailBox1", "info")
+		correctParams.Set("ForwardTo1", "info@gmail.com")
+		correctParams.Set("MailBox2", "sales")
+		correctParams.Set("ForwardTo2", "sales@gmail.com")
+		testBody(t, r, correctParams)
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, respXML)
+	})
+
+	result, err := client.DomainDNSSetEmailForwarding("domain.com", []EmailForward{
+		{Mailbox: "info", ForwardTo: "info@gmail.com"},
+		{Mailbox: "sales", ForwardTo: "sales@gmail.com"},
+	})
+	if err != nil {
+		t.Errorf("DomainDNSSetEmailForwarding returned error: %v", err)
+	}
+
+	want := &DomainDNSSetEmailForwardResult{Domain: "domain.com", IsSuccess: true}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("DomainDNSSetEmailForwarding returned %+v, want %+v", result, want)
+	}
+
+	if _, err := client.DomainDNSSetEmailForwarding("domain.com", []EmailForward{{Mailbox: "info"}}); err == nil {
+		t.Error("DomainDNSSetEmailForwarding should reject a forward without an address")
+	}
 }
@@ -0,0 +1,133 @@
+// Package dnsreconcile converges the host records of a Namecheap domain on a
+// desired set, in the style of a plan/apply workflow: NewPlan reads the
+// current records and diffs them against the desired ones, Plan.String
+// renders the diff for review, and Plan.Apply writes it once confirmed.
+package dnsreconcile
+
+import (
+	"strconv"
+	"strings"
+
+	namecheap "github.com/scrambleshell/namecheap-go"
+)
+
+// ChangeKind is the kind of a Change.
+type ChangeKind int
+
+const (
+	Add ChangeKind = iota
+	Update
+	Remove
+)
+
+func (kind ChangeKind) String() string {
+	switch kind {
+	case Add:
+		return "add"
+	case Update:
+		return "update"
+	case Remove:
+		return "remove"
+	}
+	return "ChangeKind(" + strconv.Itoa(int(kind)) + ")"
+}
+
+// Change is one difference between the current and the desired records.
+// Old is unset for an Add and New is unset for a Remove. An Update keeps the
+// record's name, type and address and changes its TTL or MXPref.
+type Change struct {
+	Kind ChangeKind
+	Old  namecheap.DomainDNSHost
+	New  namecheap.DomainDNSHost
+}
+
+// Options tune how the desired records are compared with the current ones.
+type Options struct {
+	// ManagedOnly leaves current records alone unless the desired set has a
+	// record with the same name and type. Records of a managed name and type
+	// that are not desired are still removed.
+	ManagedOnly bool
+}
+
+// Diff returns the changes that turn current into desired. Records are
+// matched on name, type and address; a matched record whose TTL or MXPref
+// differs is an Update. Changes are ordered removes, updates, then adds.
+func Diff(current, desired []namecheap.DomainDNSHost, opts Options) []Change {
+	managed := make(map[string]bool, len(desired))
+	for _, h := range desired {
+		managed[rrsetKey(h)] = true
+	}
+
+	// Pair up records with the same identity, allowing for duplicates.
+	unmatched := make(map[string][]namecheap.DomainDNSHost, len(desired))
+	for _, h := range desired {
+		unmatched[h.RecordKey()] = append(unmatched[h.RecordKey()], h)
+	}
+
+	var removes, updates, adds []Change
+	for _, h := range current {
+		key := h.RecordKey()
+		if want := unmatched[key]; len(want) > 0 {
+			unmatched[key] = want[1:]
+			if h.StateKey() != want[0].StateKey() {
+				updates = append(updates, Change{Kind: Update, Old: h, New: want[0]})
+			}
+			continue
+		}
+		if opts.ManagedOnly && !managed[rrsetKey(h)] {
+			continue
+		}
+		removes = append(removes, Change{Kind: Remove, Old: h})
+	}
+	for _, h := range desired {
+		key := h.RecordKey()
+		if want := unmatched[key]; len(want) > 0 {
+			unmatched[key] = want[1:]
+			adds = append(adds, Change{Kind: Add, New: h})
+		}
+	}
+
+	changes := make([]Change, 0, len(removes)+len(updates)+len(adds))
+	changes = append(changes, removes...)
+	changes = append(changes, updates...)
+	return append(changes, adds...)
+}
+
+// rrsetKey identifies the name and type of a record.
+func rrsetKey(h namecheap.DomainDNSHost) string {
+	return strings.ToLower(h.Name) + "\x00" + strings.ToUpper(string(h.Type))
+}
+
+// apply returns current with changes applied, keeping the order of the
+// records that stay and appending the added ones.
+func apply(current []namecheap.DomainDNSHost, changes []Change) []namecheap.DomainDNSHost {
+	removed := map[string]int{}
+	updated := map[string][]namecheap.DomainDNSHost{}
+	var added []namecheap.DomainDNSHost
+	for _, c := range changes {
+		switch c.Kind {
+		case Remove:
+			removed[c.Old.StateKey()]++
+		case Update:
+			key := c.Old.StateKey()
+			updated[key] = append(updated[key], c.New)
+		case Add:
+			added = append(added, c.New)
+		}
+	}
+
+	result := make([]namecheap.DomainDNSHost, 0, len(current)+len(added))
+	for _, h := range current {
+		key := h.StateKey()
+		if removed[key] > 0 {
+			removed[key]--
+			continue
+		}
+		if u := updated[key]; len(u) > 0 {
+			updated[key] = u[1:]
+			h = u[0]
+		}
+		result = append(result, h)
+	}
+	return append(result, added...)
+}
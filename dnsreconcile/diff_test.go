@@ -0,0 +1,69 @@
+package dnsreconcile
+
+import (
+	"reflect"
+	"testing"
+
+	namecheap "github.com/scrambleshell/namecheap-go"
+)
+
+var currentHosts = []namecheap.DomainDNSHost{
+	{ID: 1, Name: "@", Type: "A", Address: "1.2.3.4", MXPref: 10, TTL: 1800},
+	{ID: 2, Name: "www", Type: "CNAME", Address: "domain.com.", MXPref: 10, TTL: 1800},
+	{ID: 3, Name: "@", Type: "MX", Address: "mx1.example.com.", MXPref: 10, TTL: 1800},
+	{ID: 4, Name: "legacy", Type: "A", Address: "5.6.7.8", MXPref: 10, TTL: 1800},
+}
+
+func TestDiff(t *testing.T) {
+	desired := []namecheap.DomainDNSHost{
+		{Name: "@", Type: "A", Address: "1.2.3.4"},
+		{Name: "WWW", Type: "CNAME", Address: "domain.com", TTL: 300},
+		{Name: "@", Type: "MX", Address: "mx1.example.com", MXPref: 20},
+		{Name: "_acme-challenge", Type: "TXT", Address: "token"},
+	}
+
+	got := Diff(currentHosts, desired, Options{})
+	want := []Change{
+		{Kind: Remove, Old: currentHosts[3]},
+		{Kind: Update, Old: currentHosts[1], New: desired[1]},
+		{Kind: Update, Old: currentHosts[2], New: desired[2]},
+		{Kind: Add, New: desired[3]},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Diff returned %+v, want %+v", got, want)
+	}
+
+	result := apply(currentHosts, got)
+	if !namecheap.SameHosts(result, desired) {
+		t.Errorf("apply returned %+v, want %+v", result, desired)
+	}
+}
+
+func TestDiffManagedOnly(t *testing.T) {
+	desired := []namecheap.DomainDNSHost{
+		{Name: "@", Type: "A", Address: "9.9.9.9"},
+	}
+
+	got := Diff(currentHosts, desired, Options{ManagedOnly: true})
+	want := []Change{
+		{Kind: Remove, Old: currentHosts[0]},
+		{Kind: Add, New: desired[0]},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Diff returned %+v, want %+v", got, want)
+	}
+
+	result := apply(currentHosts, got)
+	if len(result) != len(currentHosts) || result[len(result)-1].Address != "9.9.9.9" {
+		t.Errorf("apply returned %+v, want the other records kept", result)
+	}
+}
+
+func TestDiffNoChanges(t *testing.T) {
+	if got := Diff(currentHosts, currentHosts, Options{}); len(got) != 0 {
+		t.Errorf("Diff of identical records returned %+v", got)
+	}
+	if got := Diff(currentHosts, nil, Options{ManagedOnly: true}); len(got) != 0 {
+		t.Errorf("managed Diff with nothing desired returned %+v", got)
+	}
+}
@@ -0,0 +1,161 @@
+package dnsreconcile
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	namecheap "github.com/scrambleshell/namecheap-go"
+)
+
+var (
+	// ErrNotConfirmed is returned by Apply when the plan was not confirmed.
+	ErrNotConfirmed = errors.New("dnsreconcile: plan was not confirmed")
+	// ErrStalePlan is returned by Apply when the records changed after the
+	// plan was made. Make a new plan and review it again.
+	ErrStalePlan = errors.New("dnsreconcile: host records changed since the plan was made")
+)
+
+// Plan is the set of changes that converges a domain on the desired records.
+type Plan struct {
+	SLD, TLD string
+	// Current is the host list the plan was made against.
+	Current []namecheap.DomainDNSHost
+	// Result is the host list Apply will write.
+	Result  []namecheap.DomainDNSHost
+	Changes []Change
+}
+
+// NewPlan reads the current host records of the domain and diffs them
+// against desired.
+func NewPlan(ctx context.Context, client *namecheap.Client, sld, tld string, desired []namecheap.DomainDNSHost, opts Options) (*Plan, error) {
+	current, err := client.DomainsDNSGetHostsContext(ctx, sld, tld)
+	if err != nil {
+		return nil, err
+	}
+	changes := Diff(current.Hosts, desired, opts)
+	return &Plan{
+		SLD:     sld,
+		TLD:     tld,
+		Current: current.Hosts,
+		Result:  apply(current.Hosts, changes),
+		Changes: changes,
+	}, nil
+}
+
+// Domain returns the domain name the plan is for.
+func (p *Plan) Domain() string {
+	return p.SLD + "." + p.TLD
+}
+
+// Empty reports whether the records already match and there is nothing to do.
+func (p *Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// Count returns the number of changes of the given kind.
+func (p *Plan) Count(kind ChangeKind) int {
+	n := 0
+	for _, c := range p.Changes {
+		if c.Kind == kind {
+			n++
+		}
+	}
+	return n
+}
+
+// String renders the plan for review, one change per line:
+//
+//	Plan for domain.com: 1 to add, 1 to update, 1 to remove.
+//
+//	  - old A 1.2.3.4 ttl=1800
+//	  ~ www CNAME domain.com. ttl=1800 -> 300
+//	  + _acme-challenge TXT "token" ttl=1800
+func (p *Plan) String() string {
+	if p.Empty() {
+		return fmt.Sprintf("No changes for %s.\n", p.Domain())
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Plan for %s: %d to add, %d to update, %d to remove.\n\n",
+		p.Domain(), p.Count(Add), p.Count(Update), p.Count(Remove))
+	for _, c := range p.Changes {
+		switch c.Kind {
+		case Add:
+			fmt.Fprintf(&b, "  + %s\n", formatHost(c.New))
+		case Remove:
+			fmt.Fprintf(&b, "  - %s\n", formatHost(c.Old))
+		case Update:
+			fmt.Fprintf(&b, "  ~ %s %s %s", c.Old.Name, c.Old.Type, formatAddress(c.Old))
+			if c.Old.EffectiveTTL() != c.New.EffectiveTTL() {
+				fmt.Fprintf(&b, " ttl=%d -> %d", c.Old.EffectiveTTL(), c.New.EffectiveTTL())
+			}
+			if strings.EqualFold(string(c.Old.Type), "MX") && c.Old.MXPref != c.New.MXPref {
+				fmt.Fprintf(&b, " pref=%d -> %d", c.Old.MXPref, c.New.MXPref)
+			}
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+func formatHost(h namecheap.DomainDNSHost) string {
+	s := fmt.Sprintf("%s %s %s ttl=%d", h.Name, h.Type, formatAddress(h), h.EffectiveTTL())
+	if strings.EqualFold(string(h.Type), "MX") {
+		s += fmt.Sprintf(" pref=%d", h.MXPref)
+	}
+	return s
+}
+
+func formatAddress(h namecheap.DomainDNSHost) string {
+	if strings.EqualFold(string(h.Type), "TXT") {
+		return fmt.Sprintf("%q", h.Address)
+	}
+	return h.Address
+}
+
+// ConfirmFunc decides whether a plan should be applied.
+type ConfirmFunc func(*Plan) bool
+
+// AutoApprove confirms every plan. Use it for unattended runs.
+func AutoApprove(*Plan) bool {
+	return true
+}
+
+// Prompt returns a ConfirmFunc that writes the plan to w and asks for it to
+// be confirmed by typing "yes" on r.
+func Prompt(r io.Reader, w io.Writer) ConfirmFunc {
+	in := bufio.NewReader(r)
+	return func(p *Plan) bool {
+		fmt.Fprint(w, p.String())
+		fmt.Fprintf(w, "\nApply these changes to %s? Only 'yes' will be accepted: ", p.Domain())
+		answer, _ := in.ReadString('\n')
+		return strings.TrimSpace(answer) == "yes"
+	}
+}
+
+// Apply writes the plan after confirm accepts it, through
+// namecheap.Client.ModifyHosts, so it holds the same per-domain lock as
+// AddHost and the other single-record helpers. ErrStalePlan is returned if
+// the records no longer match the plan; after the write they are read back
+// and a *namecheap.HostConflictError is returned if they do not match
+// Result. An empty plan is not written.
+func (p *Plan) Apply(ctx context.Context, client *namecheap.Client, confirm ConfirmFunc) error {
+	if p.Empty() {
+		return nil
+	}
+	if !confirm(p) {
+		return ErrNotConfirmed
+	}
+
+	_, err := client.ModifyHostsContext(ctx, p.SLD, p.TLD, func(current []namecheap.DomainDNSHost) ([]namecheap.DomainDNSHost, error) {
+		if !namecheap.SameHosts(current, p.Current) {
+			return nil, ErrStalePlan
+		}
+		return p.Result, nil
+	})
+	return err
+}
@@ -0,0 +1,159 @@
+package dnsreconcile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	namecheap "github.com/scrambleshell/namecheap-go"
+)
+
+// fakeAPI serves getHosts and setHosts from an in-memory record list.
+type fakeAPI struct {
+	mu    sync.Mutex
+	hosts []namecheap.DomainDNSHost
+	sets  int
+}
+
+func (f *fakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch r.PostForm.Get("Command") {
+	case "namecheap.domains.dns.getHosts":
+		fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8"?>
+<ApiResponse xmlns="http://api.namecheap.com/xml.response" Status="OK">
+  <CommandResponse Type="namecheap.domains.dns.getHosts">
+    <DomainDNSGetHostsResult Domain="domain.com" IsUsingOurDNS="true">`)
+		for i, h := range f.hosts {
+			fmt.Fprintf(w, `<host HostId="%d" Name="%s" Type="%s" Address="%s" MXPref="%d" TTL="%d" />`,
+				i+1, h.Name, h.Type, h.Address, h.MXPref, h.TTL)
+		}
+		fmt.Fprint(w, `</DomainDNSGetHostsResult></CommandResponse></ApiResponse>`)
+	case "namecheap.domains.dns.setHosts":
+		f.sets++
+		f.hosts = nil
+		for i := 1; r.PostForm.Get(fmt.Sprintf("HostName%d", i)) != ""; i++ {
+			pref, _ := strconv.Atoi(r.PostForm.Get(fmt.Sprintf("MXPref%d", i)))
+			ttl, _ := strconv.Atoi(r.PostForm.Get(fmt.Sprintf("TTL%d", i)))
+			f.hosts = append(f.hosts, namecheap.DomainDNSHost{
+				Name:    r.PostForm.Get(fmt.Sprintf("HostName%d", i)),
+				Type:    namecheap.RecordType(r.PostForm.Get(fmt.Sprintf("RecordType%d", i))),
+				Address: r.PostForm.Get(fmt.Sprintf("Address%d", i)),
+				MXPref:  pref,
+				TTL:     ttl,
+			})
+		}
+		fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8"?>
+<ApiResponse xmlns="http://api.namecheap.com/xml.response" Status="OK">
+  <CommandResponse Type="namecheap.domains.dns.setHosts">
+    <DomainDNSSetHostsResult Domain="domain.com" IsSuccess="true" />
+  </CommandResponse>
+</ApiResponse>`)
+	default:
+		http.Error(w, "unexpected command", http.StatusBadRequest)
+	}
+}
+
+func setupFake(t *testing.T) (*fakeAPI, *namecheap.Client) {
+	fake := &fakeAPI{hosts: append([]namecheap.DomainDNSHost(nil), currentHosts...)}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	client := namecheap.NewClient("anApiUser", "anToken", "anUser")
+	client.BaseURL = server.URL
+	return fake, client
+}
+
+var desiredHosts = []namecheap.DomainDNSHost{
+	{Name: "@", Type: "A", Address: "1.2.3.4"},
+	{Name: "www", Type: "CNAME", Address: "domain.com.", TTL: 300},
+	{Name: "@", Type: "MX", Address: "mx1.example.com.", MXPref: 10},
+	{Name: "_acme-challenge", Type: "TXT", Address: "token"},
+}
+
+func TestPlanString(t *testing.T) {
+	_, client := setupFake(t)
+
+	plan, err := NewPlan(context.Background(), client, "domain", "com", desiredHosts, Options{})
+	if err != nil {
+		t.Fatalf("NewPlan returned error: %v", err)
+	}
+
+	want := `Plan for domain.com: 1 to add, 1 to update, 1 to remove.
+
+  - legacy A 5.6.7.8 ttl=1800
+  ~ www CNAME domain.com. ttl=1800 -> 300
+  + _acme-challenge TXT "token" ttl=1800
+`
+	if got := plan.String(); got != want {
+		t.Errorf("Plan.String returned\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestPlanApply(t *testing.T) {
+	fake, client := setupFake(t)
+	ctx := context.Background()
+
+	plan, err := NewPlan(ctx, client, "domain", "com", desiredHosts, Options{})
+	if err != nil {
+		t.Fatalf("NewPlan returned error: %v", err)
+	}
+
+	var out strings.Builder
+	if err := plan.Apply(ctx, client, Prompt(strings.NewReader("no\n"), &out)); !errors.Is(err, ErrNotConfirmed) {
+		t.Errorf("Apply returned %v, want ErrNotConfirmed", err)
+	}
+	if fake.sets != 0 {
+		t.Errorf("unconfirmed Apply wrote %d times", fake.sets)
+	}
+	if !strings.Contains(out.String(), "Plan for domain.com") {
+		t.Errorf("Prompt did not show the plan, wrote %q", out.String())
+	}
+
+	if err := plan.Apply(ctx, client, Prompt(strings.NewReader("yes\n"), &out)); err != nil {
+		t.Fatalf("Apply returned error: %v", err)
+	}
+	if !namecheap.SameHosts(fake.hosts, desiredHosts) {
+		t.Errorf("Apply left %+v, want %+v", fake.hosts, desiredHosts)
+	}
+
+	again, err := NewPlan(ctx, client, "domain", "com", desiredHosts, Options{})
+	if err != nil {
+		t.Fatalf("NewPlan returned error: %v", err)
+	}
+	if !again.Empty() {
+		t.Errorf("plan after Apply is not empty:\n%s", again)
+	}
+}
+
+func TestPlanApplyStale(t *testing.T) {
+	fake, client := setupFake(t)
+	ctx := context.Background()
+
+	plan, err := NewPlan(ctx, client, "domain", "com", desiredHosts, Options{})
+	if err != nil {
+		t.Fatalf("NewPlan returned error: %v", err)
+	}
+
+	fake.mu.Lock()
+	fake.hosts = fake.hosts[1:]
+	fake.mu.Unlock()
+
+	if err := plan.Apply(ctx, client, AutoApprove); !errors.Is(err, ErrStalePlan) {
+		t.Errorf("Apply returned %v, want ErrStalePlan", err)
+	}
+	if fake.sets != 0 {
+		t.Errorf("stale Apply wrote %d times", fake.sets)
+	}
+}
@@ -1,19 +1,26 @@
 package namecheap
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 )
 
 const (
-	domainsGetList = "namecheap.domains.getList"
-	domainsGetInfo = "namecheap.domains.getInfo"
-	domainsCheck   = "namecheap.domains.check"
-	domainsCreate  = "namecheap.domains.create"
-	domainsTLDList = "namecheap.domains.getTldList"
-	domainsRenew   = "namecheap.domains.renew"
+	domainsGetList          = "namecheap.domains.getList"
+	domainsGetInfo          = "namecheap.domains.getInfo"
+	domainsCheck            = "namecheap.domains.check"
+	domainsCreate           = "namecheap.domains.create"
+	domainsTLDList          = "namecheap.domains.getTldList"
+	domainsRenew            = "namecheap.domains.renew"
+	domainsGetContacts      = "namecheap.domains.getContacts"
+	domainsSetContacts      = "namecheap.domains.setContacts"
+	domainsGetRegistrarLock = "namecheap.domains.getRegistrarLock"
+	domainsSetRegistrarLock = "namecheap.domains.setRegistrarLock"
 	// Domain `ListType` Filter
 	// https://www.namecheap.com/support/api/methods/domains/get-list.aspx
 	ALL      = "ALL"
@@ -27,6 +34,10 @@ const (
 	EXPIRE_DATE_DESC = "EXPIREDATE_DESC"
 	CREATE_DATE_ASC  = "CREATEDATE"
 	CREATE_DATE_DESC = "CREATEDATE_DESC"
+	// Domain `LockAction`
+	// https://www.namecheap.com/support/api/methods/domains/set-registrar-lock.aspx
+	LOCK   = "LOCK"
+	UNLOCK = "UNLOCK"
 )
 
 // DomainGetListResult represents the data returned by 'domains.getList'
@@ -34,8 +45,8 @@ type DomainGetListResult struct {
 	ID         int    `xml:"ID,attr"`
 	Name       string `xml:"Name,attr"`
 	User       string `xml:"User,attr"`
-	Created    string `xml:"Created,attr"`
-	Expires    string `xml:"Expires,attr"`
+	Created    Date   `xml:"Created,attr"`
+	Expires    Date   `xml:"Expires,attr"`
 	IsExpired  bool   `xml:"IsExpired,attr"`
 	IsLocked   bool   `xml:"IsLocked,attr"`
 	AutoRenew  bool   `xml:"AutoRenew,attr"`
@@ -53,8 +64,8 @@ type DomainInfo struct {
 	ID         int        `xml:"ID,attr"`
 	Name       string     `xml:"DomainName,attr"`
 	Owner      string     `xml:"OwnerName,attr"`
-	Created    string     `xml:"DomainDetails>CreatedDate"`
-	Expires    string     `xml:"DomainDetails>ExpiredDate"`
+	Created    Date       `xml:"DomainDetails>CreatedDate"`
+	Expires    Date       `xml:"DomainDetails>ExpiredDate"`
 	IsExpired  bool       `xml:"IsExpired,attr"`
 	IsLocked   bool       `xml:"IsLocked,attr"`
 	AutoRenew  bool       `xml:"AutoRenew,attr"`
@@ -62,6 +73,40 @@ type DomainInfo struct {
 	Whoisguard Whoisguard `xml:"Whoisguard"`
 }
 
+// CreatedString returns Created as the API sent it.
+func (domain DomainGetListResult) CreatedString() string {
+	return domain.Created.String()
+}
+
+// ExpiresString returns Expires as the API sent it.
+func (domain DomainGetListResult) ExpiresString() string {
+	return domain.Expires.String()
+}
+
+// DaysUntilExpiry returns the number of days left before the domain expires,
+// negative once it has expired. Expires is zero when the API sent no date or
+// one Date cannot parse; check it with Expires.IsZero first.
+func (domain DomainGetListResult) DaysUntilExpiry() int {
+	return domain.Expires.DaysUntil(time.Now())
+}
+
+// CreatedString returns Created as the API sent it.
+func (info *DomainInfo) CreatedString() string {
+	return info.Created.String()
+}
+
+// ExpiresString returns Expires as the API sent it.
+func (info *DomainInfo) ExpiresString() string {
+	return info.Expires.String()
+}
+
+// DaysUntilExpiry returns the number of days left before the domain expires,
+// negative once it has expired. Check Expires.IsZero first, as for
+// DomainGetListResult.DaysUntilExpiry.
+func (info *DomainInfo) DaysUntilExpiry() int {
+	return info.Expires.DaysUntil(time.Now())
+}
+
 type DNSDetails struct {
 	ProviderType  string   `xml:"ProviderType,attr"`
 	IsUsingOurDNS bool     `xml:"IsUsingOurDNS,attr"`
@@ -69,9 +114,14 @@ type DNSDetails struct {
 }
 
 type Whoisguard struct {
-	Enabled     bool   `xml:"Enabled,attr"`
-	ID          int64  `xml:"ID"`
-	ExpiredDate string `xml:"ExpiredDate"`
+	Enabled     bool  `xml:"Enabled,attr"`
+	ID          int64 `xml:"ID"`
+	ExpiredDate Date  `xml:"ExpiredDate"`
+}
+
+// ExpiredDateString returns ExpiredDate as the API sent it.
+func (wg Whoisguard) ExpiredDateString() string {
+	return wg.ExpiredDate.String()
 }
 
 type DomainCheckResult struct {
@@ -107,7 +157,54 @@ type DomainRenewResult struct {
 	ChargedAmount float64 `xml:"ChargedAmount,attr"`
 	OrderID       int     `xml:"OrderID,attr"`
 	TransactionID int     `xml:"TransactionID,attr"`
-	ExpireDate    string  `xml:"DomainDetails>ExpiredDate"`
+	ExpireDate    Date    `xml:"DomainDetails>ExpiredDate"`
+}
+
+// ExpireDateString returns ExpireDate as the API sent it.
+func (result *DomainRenewResult) ExpireDateString() string {
+	return result.ExpireDate.String()
+}
+
+// DomainContact is one contact block returned by 'domains.getContacts'.
+// The fields are named like the Registrant fields without their prefix.
+type DomainContact struct {
+	FirstName     string `xml:"FirstName"`
+	LastName      string `xml:"LastName"`
+	Address1      string `xml:"Address1"`
+	Address2      string `xml:"Address2"`
+	City          string `xml:"City"`
+	StateProvince string `xml:"StateProvince"`
+	PostalCode    string `xml:"PostalCode"`
+	Country       string `xml:"Country"`
+	Phone         string `xml:"Phone"`
+	EmailAddress  string `xml:"EmailAddress"`
+}
+
+// DomainContactsResult represents the data returned by 'domains.getContacts'
+type DomainContactsResult struct {
+	Domain     string        `xml:"Domain,attr"`
+	Registrant DomainContact `xml:"Registrant"`
+	Tech       DomainContact `xml:"Tech"`
+	Admin      DomainContact `xml:"Admin"`
+	AuxBilling DomainContact `xml:"AuxBilling"`
+}
+
+// DomainSetContactsResult represents the data returned by 'domains.setContacts'
+type DomainSetContactsResult struct {
+	Domain    string `xml:"Domain,attr"`
+	IsSuccess bool   `xml:"IsSuccess,attr"`
+}
+
+// DomainGetRegistrarLockResult represents the data returned by 'domains.getRegistrarLock'
+type DomainGetRegistrarLockResult struct {
+	Domain              string `xml:"Domain,attr"`
+	RegistrarLockStatus bool   `xml:"RegistrarLockStatus,attr"`
+}
+
+// DomainSetRegistrarLockResult represents the data returned by 'domains.setRegistrarLock'
+type DomainSetRegistrarLockResult struct {
+	Domain    string `xml:"Domain,attr"`
+	IsSuccess bool   `xml:"IsSuccess,attr"`
 }
 
 type DomainCreateOption struct {
@@ -117,7 +214,12 @@ type DomainCreateOption struct {
 }
 
 func (client *Client) DomainCount() (uint, error) {
-	r, err := client.DomainsListAPIRequest(1, 1, "", "", "")
+	return client.DomainCountContext(context.Background())
+}
+
+// DomainCountContext is like DomainCount but uses ctx for the underlying request.
+func (client *Client) DomainCountContext(ctx context.Context) (uint, error) {
+	r, err := client.DomainsListAPIRequestContext(ctx, 1, 1, "", "", "")
 	if err != nil {
 		return 0, err
 	}
@@ -127,49 +229,125 @@ func (client *Client) DomainCount() (uint, error) {
 // TODO: These function names are kinda awful, a overhaul of the library should address renaming these to give
 // a more readable API and library usage that is intiutive, then alias to make it backwards compatible
 func (client *Client) DomainsGetList(currentPage uint, pageSize uint) ([]DomainGetListResult, Paging, error) {
-	r, err := client.DomainsListAPIRequest(currentPage, pageSize, "", "", "")
+	return client.DomainsGetListContext(context.Background(), currentPage, pageSize)
+}
+
+// DomainsGetListContext is like DomainsGetList but uses ctx for the underlying request.
+func (client *Client) DomainsGetListContext(ctx context.Context, currentPage uint, pageSize uint) ([]DomainGetListResult, Paging, error) {
+	return client.DomainsListContext(ctx, DomainListFilter{
+		Page:     ValidateCurrentPage(currentPage),
+		PageSize: ValidatePageSize(pageSize),
+	})
+}
+
+// DomainListFilter selects and orders the domains returned by DomainsList.
+// Zero values are left to the API defaults, except Page and PageSize, which
+// default to 1 and 20.
+type DomainListFilter struct {
+	// SearchTerm matches domains containing it. Terms shorter than 2
+	// characters are ignored.
+	SearchTerm string
+	// ListType is ALL, EXPIRING or EXPIRED.
+	ListType string
+	// SortBy is one of NAME_ASC, NAME_DESC, EXPIRE_DATE_ASC,
+	// EXPIRE_DATE_DESC, CREATE_DATE_ASC or CREATE_DATE_DESC.
+	SortBy   string
+	Page     uint
+	PageSize uint
+}
+
+// addValues checks the filter and adds it to the request parameters.
+func (filter DomainListFilter) addValues(params url.Values) error {
+	searchTerm, err := ValidateSearchTerm(filter.SearchTerm)
+	if err != nil {
+		return err
+	}
+	if filter.ListType != "" && ValidateListType(filter.ListType) != strings.ToUpper(filter.ListType) {
+		return fmt.Errorf("invalid ListType %q, must be %s, %s or %s", filter.ListType, ALL, EXPIRING, EXPIRED)
+	}
+	if filter.SortBy != "" && ValidateSortBy(filter.SortBy) != strings.ToUpper(filter.SortBy) {
+		return fmt.Errorf("invalid SortBy %q", filter.SortBy)
+	}
+	if filter.Page > maxCurrentPage {
+		return fmt.Errorf("invalid Page %d, must be at most %d", filter.Page, maxCurrentPage)
+	}
+	if filter.PageSize != 0 && (filter.PageSize < minPerPage || filter.PageSize > maxPerPage) {
+		return fmt.Errorf("invalid PageSize %d, must be between %d and %d", filter.PageSize, minPerPage, maxPerPage)
+	}
+
+	page, pageSize := filter.Page, filter.PageSize
+	if page == 0 {
+		page = minCurrentPage
+	}
+	if pageSize == 0 {
+		pageSize = defaultPerPage
+	}
+	params.Set("page", strconv.Itoa(int(page)))
+	params.Set("pageSize", strconv.Itoa(int(pageSize)))
+	if searchTerm != "" {
+		params.Set("SearchTerm", searchTerm)
+	}
+	if filter.ListType != "" {
+		params.Set("ListType", strings.ToUpper(filter.ListType))
+	}
+	if filter.SortBy != "" {
+		params.Set("SortBy", strings.ToUpper(filter.SortBy))
+	}
+	return nil
+}
+
+// DomainsList returns one page of the domains matching filter.
+func (client *Client) DomainsList(filter DomainListFilter) ([]DomainGetListResult, Paging, error) {
+	return client.DomainsListContext(context.Background(), filter)
+}
+
+// DomainsListContext is like DomainsList but uses ctx for the underlying request.
+func (client *Client) DomainsListContext(ctx context.Context, filter DomainListFilter) ([]DomainGetListResult, Paging, error) {
+	r, err := client.domainsList(ctx, filter)
+	if err != nil {
+		return nil, Paging{}, err
+	}
 	p := Paging{
 		TotalItems:  r.TotalItems,
 		CurrentPage: r.CurrentPage,
 		PageSize:    r.PageSize,
 	}
-	return r.Domains, p, err
+	return r.Domains, p, nil
 }
 
-func (client *Client) DomainsGetCompleteList() (domains []DomainGetListResult, err error) {
-	r, err := client.DomainsListAPIRequest(1, maxPerPage, "", "", "")
-	if err != nil {
+func (client *Client) domainsList(ctx context.Context, filter DomainListFilter) (*ApiResponse, error) {
+	requestInfo := &ApiRequest{
+		command: domainsGetList,
+		method:  "POST",
+		params:  url.Values{},
+	}
+	if err := filter.addValues(requestInfo.params); err != nil {
 		return nil, err
 	}
+	return client.do(ctx, requestInfo)
+}
 
-	// Load first 100 items into map
-	domains = append(domains, r.Domains...)
-	if r.TotalItems > maxPerPage {
-		remaining := (r.TotalItems - maxPerPage)
-		quotient := (remaining / maxPerPage)
-		if quotient != 0 {
-			// Start from the second page because the initial apge is scrapped to get the
-			// initial paging object and so +2 is added to quotient to request each page, and
-			// an additonal +1 to request the remainder
-			for currentPage := 2; uint(currentPage) < (quotient + 3); currentPage++ {
-				r, err = client.DomainsListAPIRequest(uint(currentPage), maxPerPage, "", "", "")
-				if err != nil {
-					return domains, err
-				}
-				domains = append(domains, r.Domains...)
-			}
-		} else {
-			r, err = client.DomainsListAPIRequest(2, maxPerPage, "", "", "")
-			if err != nil {
-				return domains, err
-			}
-			domains = append(domains, r.Domains...)
-		}
+func (client *Client) DomainsGetCompleteList() (domains []DomainGetListResult, err error) {
+	return client.DomainsGetCompleteListContext(context.Background())
+}
+
+// DomainsGetCompleteListContext is like DomainsGetCompleteList but uses ctx for the underlying request.
+// On failure it returns the domains fetched so far along with the error; use
+// IterateDomains to process large accounts without holding every domain.
+func (client *Client) DomainsGetCompleteListContext(ctx context.Context) (domains []DomainGetListResult, err error) {
+	it := client.IterateDomainsContext(ctx, DomainListFilter{})
+	for it.Next() {
+		domains = append(domains, it.Domain())
 	}
-	return domains, nil
+	return domains, it.Err()
 }
 
 func (client *Client) DomainGetInfo(domainName string) (*DomainInfo, error) {
+	return client.DomainGetInfoContext(context.Background(), domainName)
+}
+
+// DomainGetInfoContext is like DomainGetInfo but uses ctx for the underlying request.
+func (client *Client) DomainGetInfoContext(ctx context.Context, domainName string) (*DomainInfo, error) {
 	requestInfo := &ApiRequest{
 		command: domainsGetInfo,
 		method:  "POST",
@@ -177,7 +355,7 @@ func (client *Client) DomainGetInfo(domainName string) (*DomainInfo, error) {
 	}
 	requestInfo.params.Set("DomainName", domainName)
 
-	r, err := client.do(requestInfo)
+	r, err := client.do(ctx, requestInfo)
 	if err != nil {
 		return nil, err
 	}
@@ -185,6 +363,11 @@ func (client *Client) DomainGetInfo(domainName string) (*DomainInfo, error) {
 }
 
 func (client *Client) DomainsCheck(domainNames ...string) ([]DomainCheckResult, error) {
+	return client.DomainsCheckContext(context.Background(), domainNames...)
+}
+
+// DomainsCheckContext is like DomainsCheck but uses ctx for the underlying request.
+func (client *Client) DomainsCheckContext(ctx context.Context, domainNames ...string) ([]DomainCheckResult, error) {
 	requestInfo := &ApiRequest{
 		command: domainsCheck,
 		method:  "POST",
@@ -192,7 +375,7 @@ func (client *Client) DomainsCheck(domainNames ...string) ([]DomainCheckResult,
 	}
 
 	requestInfo.params.Set("DomainList", strings.Join(domainNames, ","))
-	r, err := client.do(requestInfo)
+	r, err := client.do(ctx, requestInfo)
 	if err != nil {
 		return nil, err
 	}
@@ -201,13 +384,18 @@ func (client *Client) DomainsCheck(domainNames ...string) ([]DomainCheckResult,
 }
 
 func (client *Client) DomainsTLDList(currentPage int) ([]TLDListResult, Paging, error) {
+	return client.DomainsTLDListContext(context.Background(), currentPage)
+}
+
+// DomainsTLDListContext is like DomainsTLDList but uses ctx for the underlying request.
+func (client *Client) DomainsTLDListContext(ctx context.Context, currentPage int) ([]TLDListResult, Paging, error) {
 	requestInfo := &ApiRequest{
 		command: domainsTLDList,
 		method:  "POST",
 		params:  url.Values{},
 	}
 
-	r, err := client.do(requestInfo)
+	r, err := client.do(ctx, requestInfo)
 	if err != nil {
 		return nil, Paging{}, err
 	}
@@ -215,6 +403,15 @@ func (client *Client) DomainsTLDList(currentPage int) ([]TLDListResult, Paging,
 }
 
 func (client *Client) DomainCreate(domainName string, years int, options ...DomainCreateOption) (*DomainCreateResult, error) {
+	return client.DomainCreateContext(context.Background(), domainName, years, options...)
+}
+
+// DomainCreateContext is like DomainCreate but uses ctx for the underlying request.
+func (client *Client) DomainCreateContext(ctx context.Context, domainName string, years int, options ...DomainCreateOption) (*DomainCreateResult, error) {
+	if err := client.checkSpending(); err != nil {
+		return nil, err
+	}
+
 	if client.Registrant == nil {
 		return nil, errors.New("Registrant information on client cannot be empty")
 	}
@@ -242,7 +439,7 @@ func (client *Client) DomainCreate(domainName string, years int, options ...Doma
 		return nil, err
 	}
 
-	r, err := client.do(requestInfo)
+	r, err := client.do(ctx, requestInfo)
 	if err != nil {
 		return nil, err
 	}
@@ -251,6 +448,15 @@ func (client *Client) DomainCreate(domainName string, years int, options ...Doma
 }
 
 func (client *Client) DomainRenew(domainName string, years int) (*DomainRenewResult, error) {
+	return client.DomainRenewContext(context.Background(), domainName, years)
+}
+
+// DomainRenewContext is like DomainRenew but uses ctx for the underlying request.
+func (client *Client) DomainRenewContext(ctx context.Context, domainName string, years int) (*DomainRenewResult, error) {
+	if err := client.checkSpending(); err != nil {
+		return nil, err
+	}
+
 	requestInfo := &ApiRequest{
 		command: domainsRenew,
 		method:  "POST",
@@ -259,10 +465,160 @@ func (client *Client) DomainRenew(domainName string, years int) (*DomainRenewRes
 	requestInfo.params.Set("DomainName", domainName)
 	requestInfo.params.Set("Years", strconv.Itoa(years))
 
-	resp, err := client.do(requestInfo)
+	resp, err := client.do(ctx, requestInfo)
 	if err != nil {
 		return nil, err
 	}
 
 	return resp.DomainRenew, nil
 }
+
+func (client *Client) DomainGetContacts(domainName string) (*DomainContactsResult, error) {
+	return client.DomainGetContactsContext(context.Background(), domainName)
+}
+
+// DomainGetContactsContext is like DomainGetContacts but uses ctx for the underlying request.
+func (client *Client) DomainGetContactsContext(ctx context.Context, domainName string) (*DomainContactsResult, error) {
+	requestInfo := &ApiRequest{
+		command: domainsGetContacts,
+		method:  "POST",
+		params:  url.Values{},
+	}
+	requestInfo.params.Set("DomainName", domainName)
+
+	resp, err := client.do(ctx, requestInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.DomainContacts, nil
+}
+
+// DomainSetContacts replaces all four contacts of the domain with the ones in
+// registrant. Use DomainContactsResult.ToRegistrant to start from the current
+// contacts.
+func (client *Client) DomainSetContacts(domainName string, registrant *Registrant) (*DomainSetContactsResult, error) {
+	return client.DomainSetContactsContext(context.Background(), domainName, registrant)
+}
+
+// DomainSetContactsContext is like DomainSetContacts but uses ctx for the underlying request.
+func (client *Client) DomainSetContactsContext(ctx context.Context, domainName string, registrant *Registrant) (*DomainSetContactsResult, error) {
+	if registrant == nil {
+		return nil, errors.New("Registrant information cannot be empty")
+	}
+
+	requestInfo := &ApiRequest{
+		command: domainsSetContacts,
+		method:  "POST",
+		params:  url.Values{},
+	}
+	requestInfo.params.Set("DomainName", domainName)
+	if err := registrant.addValues(requestInfo.params); err != nil {
+		return nil, err
+	}
+
+	resp, err := client.do(ctx, requestInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.DomainSetContacts, nil
+}
+
+func (client *Client) DomainGetRegistrarLock(domainName string) (*DomainGetRegistrarLockResult, error) {
+	return client.DomainGetRegistrarLockContext(context.Background(), domainName)
+}
+
+// DomainGetRegistrarLockContext is like DomainGetRegistrarLock but uses ctx for the underlying request.
+func (client *Client) DomainGetRegistrarLockContext(ctx context.Context, domainName string) (*DomainGetRegistrarLockResult, error) {
+	requestInfo := &ApiRequest{
+		command: domainsGetRegistrarLock,
+		method:  "POST",
+		params:  url.Values{},
+	}
+	requestInfo.params.Set("DomainName", domainName)
+
+	resp, err := client.do(ctx, requestInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.DomainGetRegistrarLock, nil
+}
+
+// DomainSetRegistrarLock locks or unlocks the domain; lockAction is LOCK or UNLOCK.
+func (client *Client) DomainSetRegistrarLock(domainName, lockAction string) (*DomainSetRegistrarLockResult, error) {
+	return client.DomainSetRegistrarLockContext(context.Background(), domainName, lockAction)
+}
+
+// DomainSetRegistrarLockContext is like DomainSetRegistrarLock but uses ctx for the underlying request.
+func (client *Client) DomainSetRegistrarLockContext(ctx context.Context, domainName, lockAction string) (*DomainSetRegistrarLockResult, error) {
+	if lockAction != LOCK && lockAction != UNLOCK {
+		return nil, fmt.Errorf("invalid LockAction %q, must be %s or %s", lockAction, LOCK, UNLOCK)
+	}
+
+	requestInfo := &ApiRequest{
+		command: domainsSetRegistrarLock,
+		method:  "POST",
+		params:  url.Values{},
+	}
+	requestInfo.params.Set("DomainName", domainName)
+	requestInfo.params.Set("LockAction", lockAction)
+
+	resp, err := client.do(ctx, requestInfo)
+	if err != nil {
+		return nil, err
+	}
+	if resp.DomainSetRegistrarLock == nil || !resp.DomainSetRegistrarLock.IsSuccess {
+		return resp.DomainSetRegistrarLock, fmt.Errorf("%s of %s was not successful", lockAction, domainName)
+	}
+
+	return resp.DomainSetRegistrarLock, nil
+}
+
+// DomainWithRegistrarUnlocked unlocks the domain, runs fn and locks the domain
+// again, whether fn succeeds, fails or panics. fn is not run if the unlock
+// fails. Errors from fn and from re-locking are both returned.
+func (client *Client) DomainWithRegistrarUnlocked(domainName string, fn func() error) error {
+	return client.DomainWithRegistrarUnlockedContext(context.Background(), domainName, fn)
+}
+
+// DomainWithRegistrarUnlockedContext is like DomainWithRegistrarUnlocked but
+// uses ctx for the underlying requests. The domain is re-locked even if ctx
+// is canceled while fn runs, and also when the unlock failed in a way that
+// leaves open whether Namecheap applied it, such as a timeout.
+func (client *Client) DomainWithRegistrarUnlockedContext(ctx context.Context, domainName string, fn func() error) error {
+	result, err := client.DomainSetRegistrarLockContext(ctx, domainName, UNLOCK)
+	if err != nil {
+		if result != nil || !mayHaveApplied(err) {
+			return err
+		}
+		return errors.Join(err, client.relock(ctx, domainName))
+	}
+
+	returned := false
+	defer func() {
+		// fn panicked: lock the domain before the panic carries on.
+		if !returned {
+			client.relock(ctx, domainName)
+		}
+	}()
+	fnErr := fn()
+	returned = true
+	return errors.Join(fnErr, client.relock(ctx, domainName))
+}
+
+func (client *Client) relock(ctx context.Context, domainName string) error {
+	_, err := client.DomainSetRegistrarLockContext(context.WithoutCancel(ctx), domainName, LOCK)
+	if err != nil {
+		return fmt.Errorf("re-locking %s: %w", domainName, err)
+	}
+	return nil
+}
+
+// mayHaveApplied reports whether a command that failed with err may still
+// have been carried out. Only an answer from the API rules that out.
+func mayHaveApplied(err error) bool {
+	var apiErr *ApiError
+	return !errors.As(err, &apiErr)
+}
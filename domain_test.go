@@ -1,6 +1,7 @@
 package namecheap
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -36,12 +37,14 @@ func TestDomainsGetList(t *testing.T) {
 	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		correctParams := fillDefaultParams(url.Values{})
 		correctParams.Set("Command", "namecheap.domains.getList")
+		correctParams.Set("page", "1")
+		correctParams.Set("pageSize", "20")
 		testBody(t, r, correctParams)
 		testMethod(t, r, "POST")
 		fmt.Fprint(w, respXML)
 	})
 
-	domains, err := client.DomainsGetList()
+	domains, _, err := client.DomainsGetList(1, 20)
 
 	if err != nil {
 		t.Errorf("DomainsGetList returned error: %v", err)
@@ -52,8 +55,8 @@ func TestDomainsGetList(t *testing.T) {
 		ID:         57579,
 		Name:       "example.com",
 		User:       "anUser",
-		Created:    "11/04/2014",
-		Expires:    "11/04/2015",
+		Created:    mustParseDate(t, "11/04/2014"),
+		Expires:    mustParseDate(t, "11/04/2015"),
 		IsExpired:  false,
 		IsLocked:   false,
 		AutoRenew:  false,
@@ -122,8 +125,8 @@ func TestDomainGetInfo(t *testing.T) {
 		ID:        57582,
 		Name:      "example.com",
 		Owner:     "anUser",
-		Created:   "11/04/2014",
-		Expires:   "11/04/2015",
+		Created:   mustParseDate(t, "11/04/2014"),
+		Expires:   mustParseDate(t, "11/04/2015"),
 		IsExpired: false,
 		IsLocked:  false,
 		AutoRenew: false,
@@ -141,7 +144,7 @@ func TestDomainGetInfo(t *testing.T) {
 		Whoisguard: Whoisguard{
 			Enabled:     true,
 			ID:          53536,
-			ExpiredDate: "11/04/2015",
+			ExpiredDate: mustParseDate(t, "11/04/2015"),
 		},
 	}
 
@@ -315,9 +318,583 @@ func TestDomainsRenew(t *testing.T) {
 		ChargedAmount: 650,
 		TransactionID: 119569,
 		OrderID:       109116,
-		ExpireDate:    "4/30/2021 11:31:13 AM",
+		ExpireDate:    mustParseDate(t, "4/30/2021 11:31:13 AM"),
 	}
 	if !reflect.DeepEqual(result, want) {
 		t.Errorf("DomainRenew returned %+v, want %+v", result, want)
 	}
 }
+
+const domainGetContactsXML = `<?xml version="1.0" encoding="UTF-8"?>
+<ApiResponse xmlns="http://api.namecheap.com/xml.response" Status="OK">
+  <Errors />
+  <RequestedCommand>namecheap.domains.getContacts</RequestedCommand>
+  <CommandResponse Type="namecheap.domains.getContacts">
+    <DomainContactsResult Domain="domain1.com" domainnameid="3152456">
+      <Registrant ReadOnly="false">
+        <OrganizationName>NameCheap.com</OrganizationName>
+        <FirstName>John</FirstName>
+        <LastName>Smith</LastName>
+        <Address1>8939 S.cross Blvd</Address1>
+        <Address2>ca 110-708</Address2>
+        <City>CA</City>
+        <StateProvince>CA</StateProvince>
+        <PostalCode>90045</PostalCode>
+        <Country>US</Country>
+        <Phone>+1.6613102107</Phone>
+        <EmailAddress>john@gmail.com</EmailAddress>
+      </Registrant>
+      <Tech ReadOnly="false">
+        <FirstName>Jane</FirstName>
+        <LastName>Doe</LastName>
+        <Address1>1 Tech Way</Address1>
+        <Address2 />
+        <City>Austin</City>
+        <StateProvince>TX</StateProvince>
+        <PostalCode>73301</PostalCode>
+        <Country>US</Country>
+        <Phone>+1.5125550100</Phone>
+        <EmailAddress>jane@example.com</EmailAddress>
+      </Tech>
+      <Admin ReadOnly="false">
+        <FirstName>John</FirstName>
+        <LastName>Smith</LastName>
+        <Address1>8939 S.cross Blvd</Address1>
+        <Address2>ca 110-708</Address2>
+        <City>CA</City>
+        <StateProvince>CA</StateProvince>
+        <PostalCode>90045</PostalCode>
+        <Country>US</Country>
+        <Phone>+1.6613102107</Phone>
+        <EmailAddress>john@gmail.com</EmailAddress>
+      </Admin>
+      <AuxBilling ReadOnly="false">
+        <FirstName>John</FirstName>
+        <LastName>Smith</LastName>
+        <Address1>8939 S.cross Blvd</Address1>
+        <Address2>ca 110-708</Address2>
+        <City>CA</City>
+        <StateProvince>CA</StateProvince>
+        <PostalCode>90045</PostalCode>
+        <Country>US</Country>
+        <Phone>+1.6613102107</Phone>
+        <EmailAddress>john@gmail.com</EmailAddress>
+      </AuxBilling>
+    </DomainContactsResult>
+  </CommandResponse>
+  <Server>SERVER-NAME</Server>
+  <GMTTimeDifference>+5</GMTTimeDifference>
+  <ExecutionTime>0.078</ExecutionTime>
+</ApiResponse>`
+
+func TestDomainGetContacts(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		correctParams := fillDefaultParams(url.Values{})
+		correctParams.Set("Command", "namecheap.domains.getContacts")
+		correctParams.Set("DomainName", "domain1.com")
+		testBody(t, r, correctParams)
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, domainGetContactsXML)
+	})
+
+	contacts, err := client.DomainGetContacts("domain1.com")
+	if err != nil {
+		t.Fatalf("DomainGetContacts returned error: %v", err)
+	}
+
+	john := DomainContact{
+		FirstName:     "John",
+		LastName:      "Smith",
+		Address1:      "8939 S.cross Blvd",
+		Address2:      "ca 110-708",
+		City:          "CA",
+		StateProvince: "CA",
+		PostalCode:    "90045",
+		Country:       "US",
+		Phone:         "+1.6613102107",
+		EmailAddress:  "john@gmail.com",
+	}
+	want := &DomainContactsResult{
+		Domain:     "domain1.com",
+		Registrant: john,
+		Tech: DomainContact{
+			FirstName:     "Jane",
+			LastName:      "Doe",
+			Address1:      "1 Tech Way",
+			City:          "Austin",
+			StateProvince: "TX",
+			PostalCode:    "73301",
+			Country:       "US",
+			Phone:         "+1.5125550100",
+			EmailAddress:  "jane@example.com",
+		},
+		Admin:      john,
+		AuxBilling: john,
+	}
+
+	if !reflect.DeepEqual(contacts, want) {
+		t.Errorf("DomainGetContacts returned %+v, want %+v", contacts, want)
+	}
+}
+
+func TestDomainSetContactsRoundTrip(t *testing.T) {
+	setup()
+	defer teardown()
+
+	respXML := `<?xml version="1.0" encoding="UTF-8"?>
+<ApiResponse xmlns="http://api.namecheap.com/xml.response" Status="OK">
+  <Errors />
+  <RequestedCommand>namecheap.domains.setContacts</RequestedCommand>
+  <CommandResponse Type="namecheap.domains.setContacts">
+    <DomainSetContactResult Domain="domain1.com" IsSuccess="true" />
+  </CommandResponse>
+  <Server>SERVER-NAME</Server>
+  <GMTTimeDifference>+5</GMTTimeDifference>
+  <ExecutionTime>0.078</ExecutionTime>
+</ApiResponse>`
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("ParseForm returned error: %v", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if r.PostForm.Get("Command") == "namecheap.domains.getContacts" {
+			fmt.Fprint(w, domainGetContactsXML)
+			return
+		}
+
+		correctParams := fillDefaultParams(url.Values{})
+		fillInfo := func(prefix, first, last, addr1, addr2, city, state, zip, phone, email string) {
+			correctParams.Set(prefix+"FirstName", first)
+			correctParams.Set(prefix+"LastName", last)
+			correctParams.Set(prefix+"Address1", addr1)
+			if addr2 != "" {
+				correctParams.Set(prefix+"Address2", addr2)
+			}
+			correctParams.Set(prefix+"City", city)
+			correctParams.Set(prefix+"StateProvince", state)
+			correctParams.Set(prefix+"PostalCode", zip)
+			correctParams.Set(prefix+"Country", "US")
+			correctParams.Set(prefix+"Phone", phone)
+			correctParams.Set(prefix+"EmailAddress", email)
+		}
+		correctParams.Set("Command", "namecheap.domains.setContacts")
+		correctParams.Set("DomainName", "domain1.com")
+		fillInfo("Registrant", "Ann", "Buyer", "1 New Owner St", "", "Boston", "MA", "02101", "+1.6175550100", "ann@acquirer.com")
+		fillInfo("Tech", "Jane", "Doe", "1 Tech Way", "", "Austin", "TX", "73301", "+1.5125550100", "jane@example.com")
+		fillInfo("Admin", "John", "Smith", "8939 S.cross Blvd", "ca 110-708", "CA", "CA", "90045", "+1.6613102107", "john@gmail.com")
+		fillInfo("AuxBilling", "John", "Smith", "8939 S.cross Blvd", "ca 110-708", "CA", "CA", "90045", "+1.6613102107", "john@gmail.com")
+		if got := r.PostForm.Encode(); got != correctParams.Encode() {
+			t.Errorf("Body:\n %v\nwant:\n %v", got, correctParams.Encode())
+		}
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, respXML)
+	})
+
+	contacts, err := client.DomainGetContacts("domain1.com")
+	if err != nil {
+		t.Fatalf("DomainGetContacts returned error: %v", err)
+	}
+
+	reg := contacts.ToRegistrant()
+	reg.RegistrantFirstName = "Ann"
+	reg.RegistrantLastName = "Buyer"
+	reg.RegistrantAddress1 = "1 New Owner St"
+	reg.RegistrantAddress2 = ""
+	reg.RegistrantCity = "Boston"
+	reg.RegistrantStateProvince = "MA"
+	reg.RegistrantPostalCode = "02101"
+	reg.RegistrantPhone = "+1.6175550100"
+	reg.RegistrantEmailAddress = "ann@acquirer.com"
+
+	result, err := client.DomainSetContacts("domain1.com", reg)
+	if err != nil {
+		t.Fatalf("DomainSetContacts returned error: %v", err)
+	}
+
+	want := &DomainSetContactsResult{
+		Domain:    "domain1.com",
+		IsSuccess: true,
+	}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("DomainSetContacts returned %+v, want %+v", result, want)
+	}
+}
+
+func TestDomainGetRegistrarLock(t *testing.T) {
+	setup()
+	defer teardown()
+
+	respXML := `<?xml version="1.0" encoding="UTF-8"?>
+<ApiResponse xmlns="http://api.namecheap.com/xml.response" Status="OK">
+  <Errors />
+  <RequestedCommand>namecheap.domains.getRegistrarLock</RequestedCommand>
+  <CommandResponse Type="namecheap.domains.getRegistrarLock">
+    <DomainGetRegistrarLockResult Domain="domain1.com" RegistrarLockStatus="true" />
+  </CommandResponse>
+  <Server>SERVER-NAME</Server>
+  <GMTTimeDifference>+5</GMTTimeDifference>
+  <ExecutionTime>0.078</ExecutionTime>
+</ApiResponse>`
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		correctParams := fillDefaultParams(url.Values{})
+		correctParams.Set("Command", "namecheap.domains.getRegistrarLock")
+		correctParams.Set("DomainName", "domain1.com")
+		testBody(t, r, correctParams)
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, respXML)
+	})
+
+	result, err := client.DomainGetRegistrarLock("domain1.com")
+	if err != nil {
+		t.Errorf("DomainGetRegistrarLock returned error: %v", err)
+	}
+	want := &DomainGetRegistrarLockResult{
+		Domain:              "domain1.com",
+		RegistrarLockStatus: true,
+	}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("DomainGetRegistrarLock returned %+v, want %+v", result, want)
+	}
+}
+
+const domainSetRegistrarLockXML = `<?xml version="1.0" encoding="UTF-8"?>
+<ApiResponse xmlns="http://api.namecheap.com/xml.response" Status="OK">
+  <Errors />
+  <RequestedCommand>namecheap.domains.setRegistrarLock</RequestedCommand>
+  <CommandResponse Type="namecheap.domains.setRegistrarLock">
+    <DomainSetRegistrarLockResult Domain="domain1.com" IsSuccess="true" />
+  </CommandResponse>
+  <Server>SERVER-NAME</Server>
+  <GMTTimeDifference>+5</GMTTimeDifference>
+  <ExecutionTime>0.078</ExecutionTime>
+</ApiResponse>`
+
+func TestDomainSetRegistrarLock(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		correctParams := fillDefaultParams(url.Values{})
+		correctParams.Set("Command", "namecheap.domains.setRegistrarLock")
+		correctParams.Set("DomainName", "domain1.com")
+		correctParams.Set("LockAction", "UNLOCK")
+		testBody(t, r, correctParams)
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, domainSetRegistrarLockXML)
+	})
+
+	result, err := client.DomainSetRegistrarLock("domain1.com", UNLOCK)
+	if err != nil {
+		t.Errorf("DomainSetRegistrarLock returned error: %v", err)
+	}
+	want := &DomainSetRegistrarLockResult{
+		Domain:    "domain1.com",
+		IsSuccess: true,
+	}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("DomainSetRegistrarLock returned %+v, want %+v", result, want)
+	}
+
+	if _, err := client.DomainSetRegistrarLock("domain1.com", "OPEN"); err == nil {
+		t.Error("DomainSetRegistrarLock with an invalid action returned no error")
+	}
+}
+
+func TestDomainWithRegistrarUnlocked(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var actions []string
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("ParseForm returned error: %v", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		actions = append(actions, r.PostForm.Get("LockAction"))
+		fmt.Fprint(w, domainSetRegistrarLockXML)
+	})
+
+	fnErr := errors.New("EPP code not available")
+	err := client.DomainWithRegistrarUnlocked("domain1.com", func() error {
+		if !reflect.DeepEqual(actions, []string{UNLOCK}) {
+			t.Errorf("callback ran after %v, want [UNLOCK]", actions)
+		}
+		return fnErr
+	})
+	if !errors.Is(err, fnErr) {
+		t.Errorf("DomainWithRegistrarUnlocked returned %v, want %v", err, fnErr)
+	}
+	if want := []string{UNLOCK, LOCK}; !reflect.DeepEqual(actions, want) {
+		t.Errorf("DomainWithRegistrarUnlocked sent %v, want %v", actions, want)
+	}
+}
+
+func TestDomainWithRegistrarUnlockedPanic(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var actions []string
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("ParseForm returned error: %v", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		actions = append(actions, r.PostForm.Get("LockAction"))
+		fmt.Fprint(w, domainSetRegistrarLockXML)
+	})
+
+	func() {
+		defer func() {
+			if p := recover(); p != "boom" {
+				t.Errorf("recovered %v, want the callback's panic", p)
+			}
+		}()
+		client.DomainWithRegistrarUnlocked("domain1.com", func() error {
+			panic("boom")
+		})
+	}()
+	if want := []string{UNLOCK, LOCK}; !reflect.DeepEqual(actions, want) {
+		t.Errorf("DomainWithRegistrarUnlocked sent %v, want %v", actions, want)
+	}
+}
+
+func TestDomainWithRegistrarUnlockedFailedUnlock(t *testing.T) {
+	tests := []struct {
+		name     string
+		unlock   func(w http.ResponseWriter)
+		wantLock bool
+	}{
+		{
+			name: "api error",
+			unlock: func(w http.ResponseWriter) {
+				fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8"?>
+<ApiResponse Status="ERROR" xmlns="http://api.namecheap.com/xml.response">
+  <Errors><Error Number="2019166">Domain not found</Error></Errors>
+</ApiResponse>`)
+			},
+		},
+		{
+			name:     "server error",
+			unlock:   func(w http.ResponseWriter) { w.WriteHeader(http.StatusBadGateway) },
+			wantLock: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			setup()
+			defer teardown()
+
+			var actions []string
+			mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+				if err := r.ParseForm(); err != nil {
+					t.Errorf("ParseForm returned error: %v", err)
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+				action := r.PostForm.Get("LockAction")
+				actions = append(actions, action)
+				if action == UNLOCK {
+					test.unlock(w)
+					return
+				}
+				fmt.Fprint(w, domainSetRegistrarLockXML)
+			})
+
+			err := client.DomainWithRegistrarUnlocked("domain1.com", func() error {
+				t.Error("callback ran after the unlock failed")
+				return nil
+			})
+			if err == nil {
+				t.Error("DomainWithRegistrarUnlocked returned no error")
+			}
+			want := []string{UNLOCK}
+			if test.wantLock {
+				want = append(want, LOCK)
+			}
+			if !reflect.DeepEqual(actions, want) {
+				t.Errorf("DomainWithRegistrarUnlocked sent %v, want %v", actions, want)
+			}
+		})
+	}
+}
+
+func TestDomainsList(t *testing.T) {
+	respXML := `<?xml version="1.0" encoding="utf-8"?>
+<ApiResponse Status="OK" xmlns="http://api.namecheap.com/xml.response">
+  <Errors />
+  <RequestedCommand>namecheap.domains.getList</RequestedCommand>
+  <CommandResponse Type="namecheap.domains.getList">
+    <DomainGetListResult>
+      <Domain ID="57579" Name="example.com" User="anUser" Created="11/04/2014" Expires="11/04/2015" IsExpired="false" IsLocked="false" AutoRenew="false" WhoisGuard="ENABLED" />
+    </DomainGetListResult>
+    <Paging>
+      <TotalItems>1</TotalItems>
+      <CurrentPage>2</CurrentPage>
+      <PageSize>50</PageSize>
+    </Paging>
+  </CommandResponse>
+</ApiResponse>`
+
+	cases := []struct {
+		name   string
+		filter DomainListFilter
+		params map[string]string
+	}{
+		{
+			name:   "defaults",
+			filter: DomainListFilter{},
+			params: map[string]string{"page": "1", "pageSize": "20"},
+		},
+		{
+			name:   "paging",
+			filter: DomainListFilter{Page: 2, PageSize: 50},
+			params: map[string]string{"page": "2", "pageSize": "50"},
+		},
+		{
+			name:   "search term",
+			filter: DomainListFilter{SearchTerm: "example.com"},
+			params: map[string]string{"page": "1", "pageSize": "20", "SearchTerm": "example.com"},
+		},
+		{
+			name:   "list type",
+			filter: DomainListFilter{ListType: EXPIRING},
+			params: map[string]string{"page": "1", "pageSize": "20", "ListType": "EXPIRING"},
+		},
+		{
+			name:   "sort order",
+			filter: DomainListFilter{SortBy: EXPIRE_DATE_DESC},
+			params: map[string]string{"page": "1", "pageSize": "20", "SortBy": "EXPIREDATE_DESC"},
+		},
+		{
+			name:   "lower case values",
+			filter: DomainListFilter{ListType: "expired", SortBy: "createdate"},
+			params: map[string]string{"page": "1", "pageSize": "20", "ListType": "EXPIRED", "SortBy": "CREATEDATE"},
+		},
+		{
+			name: "everything",
+			filter: DomainListFilter{
+				SearchTerm: "shop",
+				ListType:   ALL,
+				SortBy:     NAME_DESC,
+				Page:       3,
+				PageSize:   100,
+			},
+			params: map[string]string{
+				"page": "3", "pageSize": "100", "SearchTerm": "shop", "ListType": "ALL", "SortBy": "NAME_DESC",
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			setup()
+			defer teardown()
+
+			mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+				correctParams := fillDefaultParams(url.Values{})
+				correctParams.Set("Command", "namecheap.domains.getList")
+				for k, v := range c.params {
+					correctParams.Set(k, v)
+				}
+				testBody(t, r, correctParams)
+				testMethod(t, r, "POST")
+				fmt.Fprint(w, respXML)
+			})
+
+			domains, paging, err := client.DomainsList(c.filter)
+			if err != nil {
+				t.Fatalf("DomainsList returned error: %v", err)
+			}
+			if len(domains) != 1 || domains[0].Name != "example.com" {
+				t.Errorf("DomainsList returned %+v", domains)
+			}
+			if want := (Paging{TotalItems: 1, CurrentPage: 2, PageSize: 50}); paging != want {
+				t.Errorf("DomainsList returned paging %+v, want %+v", paging, want)
+			}
+		})
+	}
+}
+
+func TestDomainsListInvalidFilter(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("DomainsList sent a request for an invalid filter")
+	})
+
+	invalid := []DomainListFilter{
+		{SearchTerm: "bad term!"},
+		{ListType: "SOON"},
+		{SortBy: "SIZE"},
+		{Page: 1000},
+		{PageSize: 5},
+		{PageSize: 101},
+	}
+	for _, filter := range invalid {
+		if _, _, err := client.DomainsList(filter); err == nil {
+			t.Errorf("DomainsList(%+v) should have returned an error", filter)
+		}
+	}
+}
+
+func TestDomainsListAPIRequestSendsFilters(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		correctParams := fillDefaultParams(url.Values{})
+		correctParams.Set("Command", "namecheap.domains.getList")
+		correctParams.Set("page", "1")
+		correctParams.Set("pageSize", "10")
+		correctParams.Set("SearchTerm", "example")
+		correctParams.Set("ListType", "ALL")
+		correctParams.Set("SortBy", "NAME")
+		testBody(t, r, correctParams)
+		fmt.Fprint(w, `<ApiResponse Status="OK"><CommandResponse /></ApiResponse>`)
+	})
+
+	if _, err := client.DomainsListAPIRequest(0, 1, "example", "bogus", "bogus"); err != nil {
+		t.Errorf("DomainsListAPIRequest returned error: %v", err)
+	}
+}
+
+func TestValidateListFilters(t *testing.T) {
+	listTypes := map[string]string{"": ALL, "all": ALL, EXPIRING: EXPIRING, EXPIRED: EXPIRED, "bogus": ALL}
+	for in, want := range listTypes {
+		if got := ValidateListType(in); got != want {
+			t.Errorf("ValidateListType(%q) = %q, want %q", in, got, want)
+		}
+	}
+
+	sortBys := map[string]string{
+		"": NAME_ASC, NAME_DESC: NAME_DESC, EXPIRE_DATE_ASC: EXPIRE_DATE_ASC, EXPIRE_DATE_DESC: EXPIRE_DATE_DESC,
+		CREATE_DATE_ASC: CREATE_DATE_ASC, "createdate_desc": CREATE_DATE_DESC, "bogus": NAME_ASC,
+	}
+	for in, want := range sortBys {
+		if got := ValidateSortBy(in); got != want {
+			t.Errorf("ValidateSortBy(%q) = %q, want %q", in, got, want)
+		}
+	}
+
+	for _, term := range []string{"example", "example.com", "my-shop"} {
+		if got, err := ValidateSearchTerm(term); err != nil || got != term {
+			t.Errorf("ValidateSearchTerm(%q) = %q, %v, want %q", term, got, err, term)
+		}
+	}
+	if _, err := ValidateSearchTerm("bad term"); err == nil {
+		t.Error("ValidateSearchTerm should reject spaces")
+	}
+	if got, _ := ValidateSearchTerm("x"); got != "" {
+		t.Errorf("ValidateSearchTerm dropped nothing for a 1-character term, got %q", got)
+	}
+}
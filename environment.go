@@ -0,0 +1,74 @@
+package namecheap
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+)
+
+const (
+	sandboxBaseURL = "https://api.sandbox.namecheap.com/xml.response"
+	sandboxHost    = "api.sandbox.namecheap.com"
+)
+
+// ErrProductionSpending is returned by commands that charge the account, such
+// as DomainCreate, when they would run against any endpoint but the sandbox
+// and the client has not set AllowProductionSpending.
+var ErrProductionSpending = errors.New("refusing to run a spending command outside the sandbox; set AllowProductionSpending to allow it")
+
+// Environment selects which Namecheap API endpoint a Client talks to.
+type Environment int
+
+const (
+	Production Environment = iota
+	Sandbox
+)
+
+// BaseURL returns the API endpoint for the environment.
+func (env Environment) BaseURL() string {
+	if env == Sandbox {
+		return sandboxBaseURL
+	}
+	return defaultBaseURL
+}
+
+func (env Environment) String() string {
+	if env == Sandbox {
+		return "sandbox"
+	}
+	return "production"
+}
+
+// NewSandboxClient returns a client for the Namecheap sandbox at
+// https://www.sandbox.namecheap.com. Sandbox accounts and API keys are
+// separate from production ones.
+func NewSandboxClient(apiUser, apiToken, userName string) *Client {
+	client := NewClient(apiUser, apiToken, userName)
+	client.SetEnvironment(Sandbox)
+	return client
+}
+
+// SetEnvironment points the client at the base URL of env.
+func (client *Client) SetEnvironment(env Environment) {
+	client.BaseURL = env.BaseURL()
+}
+
+// IsProduction reports whether the client may be talking to the production
+// API, which is any BaseURL whose host is not the sandbox's. Proxies, test
+// servers and URLs that cannot be parsed count as production, so spending
+// commands sent to them need AllowProductionSpending too.
+func (client *Client) IsProduction() bool {
+	u, err := url.Parse(strings.TrimSpace(client.BaseURL))
+	if err != nil {
+		return true
+	}
+	return !strings.EqualFold(strings.TrimSuffix(u.Hostname(), "."), sandboxHost)
+}
+
+// checkSpending guards commands that charge the account.
+func (client *Client) checkSpending() error {
+	if client.IsProduction() && !client.AllowProductionSpending {
+		return ErrProductionSpending
+	}
+	return nil
+}
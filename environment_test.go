@@ -0,0 +1,105 @@
+package namecheap
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestNewSandboxClient(t *testing.T) {
+	c := NewSandboxClient("anApiUser", "anToken", "anUser")
+
+	if c.BaseURL != sandboxBaseURL {
+		t.Errorf("NewSandboxClient BaseURL = %v, want %v", c.BaseURL, sandboxBaseURL)
+	}
+	if c.IsProduction() {
+		t.Error("NewSandboxClient IsProduction = true, want false")
+	}
+
+	c.SetEnvironment(Production)
+	if c.BaseURL != defaultBaseURL {
+		t.Errorf("SetEnvironment(Production) BaseURL = %v, want %v", c.BaseURL, defaultBaseURL)
+	}
+}
+
+// roundTripFunc lets a plain function stand in for an http.RoundTripper.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestProductionSpendingGuard(t *testing.T) {
+	c := NewClient("anApiUser", "anToken", "anUser")
+	c.HttpClient = &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		t.Errorf("spending command reached %v", r.URL)
+		return nil, errors.New("unexpected request")
+	})}
+	c.NewRegistrant(
+		"John", "Smith",
+		"8939 S.cross Blvd", "",
+		"CA", "CA", "90045", "US",
+		"+1.6613102107", "john@gmail.com",
+	)
+
+	if _, err := c.DomainCreate("domain1.com", 1); !errors.Is(err, ErrProductionSpending) {
+		t.Errorf("DomainCreate returned error %v, want %v", err, ErrProductionSpending)
+	}
+	if _, err := c.DomainRenew("domain1.com", 1); !errors.Is(err, ErrProductionSpending) {
+		t.Errorf("DomainRenew returned error %v, want %v", err, ErrProductionSpending)
+	}
+	if _, err := c.WhoisguardRenew(1, 1); !errors.Is(err, ErrProductionSpending) {
+		t.Errorf("WhoisguardRenew returned error %v, want %v", err, ErrProductionSpending)
+	}
+}
+
+func TestIsProduction(t *testing.T) {
+	tests := []struct {
+		baseURL string
+		want    bool
+	}{
+		{defaultBaseURL, true},
+		{"http://api.namecheap.com/xml.response", true},
+		{"https://API.namecheap.com/xml.response", true},
+		{"https://api.namecheap.com:443/xml.response/", true},
+		{"http://127.0.0.1:8080/", true},
+		{"://not a url", true},
+		{sandboxBaseURL, false},
+		{"https://API.Sandbox.Namecheap.com/xml.response", false},
+		{"https://api.sandbox.namecheap.com./xml.response", false},
+	}
+	for _, test := range tests {
+		c := NewClient("anApiUser", "anToken", "anUser")
+		c.BaseURL = test.baseURL
+		if got := c.IsProduction(); got != test.want {
+			t.Errorf("IsProduction with BaseURL %q = %v, want %v", test.baseURL, got, test.want)
+		}
+	}
+}
+
+func TestProductionSpendingAllowed(t *testing.T) {
+	requests := 0
+	c := NewClient("anApiUser", "anToken", "anUser")
+	c.HttpClient = &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		requests++
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body: ioutil.NopCloser(strings.NewReader(`<?xml version="1.0" encoding="utf-8"?>
+<ApiResponse Status="OK" xmlns="http://api.namecheap.com/xml.response">
+  <CommandResponse Type="namecheap.whoisguard.renew">
+    <WhoisguardRenewResult WhoisguardId="1" Years="1" Renew="true" OrderId="1" TransactionId="1" ChargedAmount="2.8800"/>
+  </CommandResponse>
+</ApiResponse>`)),
+		}, nil
+	})}
+	c.AllowProductionSpending = true
+
+	if _, err := c.WhoisguardRenew(1, 1); err != nil {
+		t.Errorf("WhoisguardRenew returned error: %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("WhoisguardRenew sent %d requests, want 1", requests)
+	}
+}
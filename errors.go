@@ -0,0 +1,121 @@
+package namecheap
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Namecheap API Global Error Codes
+// https://www.namecheap.com/support/api/global-parameters.aspx
+const (
+	ErrCodeAPIUserMissing          = 1010101 // Parameter APIUser is missing
+	ErrCodeUnsupportedAuth         = 1030408 // Unsupported authentication type
+	ErrCodeCommandMissing          = 1010104 // Parameter Command is missing
+	ErrCodeAPIKeyMissing           = 1010102 // Parameter APIKey is missing
+	ErrCodeAPIKeyMissingAlt        = 1011102 // Parameter APIKey is missing
+	ErrCodeClientIPMissing         = 1010105 // Parameter ClientIP is missing
+	ErrCodeClientIPMissingAlt      = 1011105 // Parameter ClientIP is missing
+	ErrCodeAPIUserValidation       = 1050900 // Unknown error when validating APIUser
+	ErrCodeRequestIPInvalid        = 1011150 // Parameter RequestIP is invalid
+	ErrCodeRequestIPLocked         = 1017150 // Parameter RequestIP is disabled or locked
+	ErrCodeClientIPLocked          = 1017105 // Parameter ClientIP is disabled or locked
+	ErrCodeAPIUserLocked           = 1017101 // Parameter ApiUser is disabled or locked
+	ErrCodeTooManyDeclinedPayments = 1017410 // Too many declined payments
+	ErrCodeTooManyLoginAttempts    = 1017411 // Too many login attempts
+	ErrCodeUserNameNotAvailable    = 1019103 // Parameter UserName is not available
+	ErrCodeUserNameUnauthorized    = 1016103 // Parameter UserName is unauthorized
+	ErrCodeUserNameLocked          = 1017103 // Parameter UserName is disabled or locked
+	ErrCodeTooManyRequests         = 500000  // Too many requests
+
+	// Command specific codes returned by the domains.* commands.
+	ErrCodeDomainNotFound        = 2019166 // Domain not found
+	ErrCodeDomainNotAssociated   = 2016166 // Domain is not associated with your account
+	ErrCodeDomainNotAvailable    = 3019166 // Domain not available, i.e. taken (domains.create)
+	ErrCodeDomainNotAvailableAlt = 4019166 // Domain not available, i.e. taken (domains.create)
+
+	// Order creation failed, returned by the commands that charge the account
+	// (domains.create, domains.renew, ssl.create, whoisguard.renew, ...) for
+	// any order that could not be placed, a low balance among other reasons.
+	ErrCodeOrderCreationFailed = 2528166
+)
+
+// Error kinds for the failures callers usually need to branch on. An ApiError
+// or ApiErrors matches a kind with errors.Is when its Number maps to it:
+//
+//	if errors.Is(err, namecheap.ErrIPNotWhitelisted) { ... }
+var (
+	ErrAuthFailed        = errors.New("namecheap: authentication failed")
+	ErrIPNotWhitelisted  = errors.New("namecheap: request IP is not whitelisted")
+	ErrAccountLocked     = errors.New("namecheap: account is disabled or locked")
+	ErrParameterMissing  = errors.New("namecheap: required parameter is missing")
+	ErrDomainNotFound    = errors.New("namecheap: domain not found")
+	ErrInsufficientFunds = errors.New("namecheap: insufficient funds")
+	ErrTooManyRequests   = errors.New("namecheap: too many requests")
+)
+
+var errorKinds = map[int]error{
+	ErrCodeUnsupportedAuth:         ErrAuthFailed,
+	ErrCodeAPIUserValidation:       ErrAuthFailed,
+	ErrCodeUserNameNotAvailable:    ErrAuthFailed,
+	ErrCodeUserNameUnauthorized:    ErrAuthFailed,
+	ErrCodeRequestIPInvalid:        ErrIPNotWhitelisted,
+	ErrCodeRequestIPLocked:         ErrIPNotWhitelisted,
+	ErrCodeClientIPLocked:          ErrIPNotWhitelisted,
+	ErrCodeAPIUserLocked:           ErrAccountLocked,
+	ErrCodeUserNameLocked:          ErrAccountLocked,
+	ErrCodeTooManyDeclinedPayments: ErrAccountLocked,
+	ErrCodeTooManyLoginAttempts:    ErrAccountLocked,
+	ErrCodeAPIUserMissing:          ErrParameterMissing,
+	ErrCodeCommandMissing:          ErrParameterMissing,
+	ErrCodeAPIKeyMissing:           ErrParameterMissing,
+	ErrCodeAPIKeyMissingAlt:        ErrParameterMissing,
+	ErrCodeClientIPMissing:         ErrParameterMissing,
+	ErrCodeClientIPMissingAlt:      ErrParameterMissing,
+	ErrCodeDomainNotFound:          ErrDomainNotFound,
+	ErrCodeDomainNotAssociated:     ErrDomainNotFound,
+	ErrCodeTooManyRequests:         ErrTooManyRequests,
+}
+
+// Kind returns the error kind the error's Number maps to, or nil if it has
+// none.
+//
+// Namecheap has no number of its own for a lack of funds: it reports one as
+// an ErrCodeOrderCreationFailed error saying so. Only for that number is the
+// message looked at, to tell ErrInsufficientFunds apart from the other
+// reasons an order fails.
+func (err *ApiError) Kind() error {
+	if err.Number == ErrCodeOrderCreationFailed {
+		if strings.Contains(strings.ToLower(err.Message), "insufficient funds") {
+			return ErrInsufficientFunds
+		}
+		return nil
+	}
+	return errorKinds[err.Number]
+}
+
+// Is reports whether target is the kind of this error.
+func (err *ApiError) Is(target error) bool {
+	kind := err.Kind()
+	return kind != nil && kind == target
+}
+
+// Unwrap exposes each ApiError so that errors.Is and errors.As look at every
+// error in the response.
+func (errs ApiErrors) Unwrap() []error {
+	unwrapped := make([]error, len(errs))
+	for i := range errs {
+		unwrapped[i] = &errs[i]
+	}
+	return unwrapped
+}
+
+// HTTPError is returned when the API answers with a non-2xx HTTP status.
+type HTTPError struct {
+	StatusCode int
+}
+
+func (err *HTTPError) Error() string {
+	return fmt.Sprintf("namecheap: unexpected HTTP status %d %s", err.StatusCode, http.StatusText(err.StatusCode))
+}
@@ -0,0 +1,72 @@
+package namecheap
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestApiErrorKinds(t *testing.T) {
+	tests := []struct {
+		err  ApiError
+		want error
+	}{
+		{ApiError{Number: 1011150, Message: "Invalid request IP: 1.2.3.4"}, ErrIPNotWhitelisted},
+		{ApiError{Number: 1017105, Message: "Parameter ClientIP is disabled or locked"}, ErrIPNotWhitelisted},
+		{ApiError{Number: 1030408, Message: "Unsupported authentication type"}, ErrAuthFailed},
+		{ApiError{Number: 1017411, Message: "Too many login attempts"}, ErrAccountLocked},
+		{ApiError{Number: 1010101, Message: "Parameter APIUser is missing"}, ErrParameterMissing},
+		{ApiError{Number: 2019166, Message: "Domain not found"}, ErrDomainNotFound},
+		{ApiError{Number: 2528166, Message: "Insufficient funds in account"}, ErrInsufficientFunds},
+		{ApiError{Number: 2528166, Message: "Order creation failed"}, nil},
+		{ApiError{Number: 3019166, Message: "Domain not available"}, nil},
+		{ApiError{Number: 2030166, Message: "Domain is invalid"}, nil},
+	}
+
+	for _, tt := range tests {
+		if got := tt.err.Kind(); got != tt.want {
+			t.Errorf("ApiError{%d}.Kind() = %v, want %v", tt.err.Number, got, tt.want)
+		}
+		if tt.want != nil && !errors.Is(&tt.err, tt.want) {
+			t.Errorf("errors.Is(ApiError{%d}, %v) = false, want true", tt.err.Number, tt.want)
+		}
+	}
+}
+
+func TestApiErrorsIsAs(t *testing.T) {
+	setup()
+	defer teardown()
+
+	respXML := `<?xml version="1.0" encoding="utf-8"?>
+<ApiResponse Status="ERROR" xmlns="http://api.namecheap.com/xml.response">
+  <Errors>
+    <Error Number="1011150">Invalid request IP: 1.2.3.4</Error>
+  </Errors>
+  <Warnings />
+  <RequestedCommand />
+  <Server>WEB1-SANDBOX1</Server>
+  <GMTTimeDifference>--5:00</GMTTimeDifference>
+  <ExecutionTime>0</ExecutionTime>
+</ApiResponse>`
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, respXML)
+	})
+
+	_, err := client.DomainGetInfo("example.com")
+	if !errors.Is(err, ErrIPNotWhitelisted) {
+		t.Errorf("DomainGetInfo returned error %v, want %v", err, ErrIPNotWhitelisted)
+	}
+	if errors.Is(err, ErrAuthFailed) {
+		t.Errorf("DomainGetInfo error %v matched %v", err, ErrAuthFailed)
+	}
+
+	var apiErr *ApiError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("errors.As(%v, *ApiError) = false, want true", err)
+	}
+	if apiErr.Number != ErrCodeRequestIPInvalid {
+		t.Errorf("ApiError.Number = %d, want %d", apiErr.Number, ErrCodeRequestIPInvalid)
+	}
+}
@@ -0,0 +1,304 @@
+package namecheap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultHostTTL is the TTL Namecheap stores for a host sent without one.
+const DefaultHostTTL = 1800
+
+// ErrHostNotFound is returned by UpdateHost and DeleteHost when the domain
+// has no matching host record.
+var ErrHostNotFound = errors.New("namecheap: host record not found")
+
+// ErrHostConflict matches a *HostConflictError with errors.Is.
+var ErrHostConflict = errors.New("namecheap: host records changed concurrently")
+
+// HostConflictError is returned by ModifyHosts and the helpers built on it,
+// and by dnsreconcile, when the host records are not the ones expected,
+// because something else changed the domain at the same time: either the
+// records changed between reading them and writing the new list, or the list
+// read back after the write is not the one that was written.
+type HostConflictError struct {
+	Domain string
+	// Want is the host list that was expected, Got the list read instead.
+	Want []DomainDNSHost
+	Got  []DomainDNSHost
+}
+
+func (err *HostConflictError) Error() string {
+	return fmt.Sprintf(
+		"namecheap: host records of %s changed concurrently: expected %d records, read %d",
+		err.Domain, len(err.Want), len(err.Got),
+	)
+}
+
+// Is reports whether target is ErrHostConflict.
+func (err *HostConflictError) Is(target error) bool {
+	return target == ErrHostConflict
+}
+
+// domainLocks serialises read-modify-write cycles on the host records of a
+// domain. It is shared by every Client in the process, since clients acting
+// for the same account edit the same records. A domain's entry is dropped
+// once nobody holds or waits for its lock.
+type domainLocks struct {
+	mu    sync.Mutex
+	locks map[string]*domainLock
+}
+
+type domainLock struct {
+	ch chan struct{}
+	// refs counts the holder and the waiters.
+	refs int
+}
+
+var hostLocks = &domainLocks{locks: map[string]*domainLock{}}
+
+// lock blocks until the lock for domain is held or ctx is done. The returned
+// func releases it.
+func (l *domainLocks) lock(ctx context.Context, domain string) (func(), error) {
+	l.mu.Lock()
+	dl, ok := l.locks[domain]
+	if !ok {
+		dl = &domainLock{ch: make(chan struct{}, 1)}
+		l.locks[domain] = dl
+	}
+	dl.refs++
+	l.mu.Unlock()
+
+	select {
+	case dl.ch <- struct{}{}:
+		return func() {
+			<-dl.ch
+			l.release(domain, dl)
+		}, nil
+	case <-ctx.Done():
+		l.release(domain, dl)
+		return nil, ctx.Err()
+	}
+}
+
+func (l *domainLocks) release(domain string, dl *domainLock) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	dl.refs--
+	if dl.refs == 0 {
+		delete(l.locks, domain)
+	}
+}
+
+// AddHost adds host to the records of the domain. A record with the same
+// name, type and address is not added again; its TTL and MXPref are set to
+// those of host instead, which is a no-op when they already match.
+func (client *Client) AddHost(sld, tld string, host DomainDNSHost) (*DomainDNSGetHostsResult, error) {
+	return client.AddHostContext(context.Background(), sld, tld, host)
+}
+
+// AddHostContext is like AddHost but uses ctx for the underlying requests.
+func (client *Client) AddHostContext(ctx context.Context, sld, tld string, host DomainDNSHost) (*DomainDNSGetHostsResult, error) {
+	return client.ModifyHostsContext(ctx, sld, tld, func(hosts []DomainDNSHost) ([]DomainDNSHost, error) {
+		for i, h := range hosts {
+			if h.RecordKey() == host.RecordKey() {
+				hosts[i] = host
+				return hosts, nil
+			}
+		}
+		return append(hosts, host), nil
+	})
+}
+
+// UpdateHost replaces the record whose HostId is host.ID with host.
+func (client *Client) UpdateHost(sld, tld string, host DomainDNSHost) (*DomainDNSGetHostsResult, error) {
+	return client.UpdateHostContext(context.Background(), sld, tld, host)
+}
+
+// UpdateHostContext is like UpdateHost but uses ctx for the underlying requests.
+func (client *Client) UpdateHostContext(ctx context.Context, sld, tld string, host DomainDNSHost) (*DomainDNSGetHostsResult, error) {
+	return client.ModifyHostsContext(ctx, sld, tld, func(hosts []DomainDNSHost) ([]DomainDNSHost, error) {
+		for i, h := range hosts {
+			if host.ID != 0 && h.ID == host.ID {
+				hosts[i] = host
+				return hosts, nil
+			}
+		}
+		return nil, fmt.Errorf("%w: HostId %d", ErrHostNotFound, host.ID)
+	})
+}
+
+// DeleteHost removes a record from the domain. The record is matched on
+// host.ID when it is set and on its name, type and address otherwise.
+func (client *Client) DeleteHost(sld, tld string, host DomainDNSHost) (*DomainDNSGetHostsResult, error) {
+	return client.DeleteHostContext(context.Background(), sld, tld, host)
+}
+
+// DeleteHostContext is like DeleteHost but uses ctx for the underlying requests.
+func (client *Client) DeleteHostContext(ctx context.Context, sld, tld string, host DomainDNSHost) (*DomainDNSGetHostsResult, error) {
+	return client.ModifyHostsContext(ctx, sld, tld, func(hosts []DomainDNSHost) ([]DomainDNSHost, error) {
+		for i, h := range hosts {
+			if host.ID != 0 && h.ID == host.ID || host.ID == 0 && h.RecordKey() == host.RecordKey() {
+				return append(hosts[:i:i], hosts[i+1:]...), nil
+			}
+		}
+		return nil, fmt.Errorf("%w: %s %s %s", ErrHostNotFound, host.Name, host.Type, host.Address)
+	})
+}
+
+// UpsertHost makes host the only record of its name and type, replacing any
+// existing ones or adding it if there are none. Use AddHost for types that
+// legitimately hold several values for one name, such as TXT.
+func (client *Client) UpsertHost(sld, tld string, host DomainDNSHost) (*DomainDNSGetHostsResult, error) {
+	return client.UpsertHostContext(context.Background(), sld, tld, host)
+}
+
+// UpsertHostContext is like UpsertHost but uses ctx for the underlying requests.
+func (client *Client) UpsertHostContext(ctx context.Context, sld, tld string, host DomainDNSHost) (*DomainDNSGetHostsResult, error) {
+	return client.ModifyHostsContext(ctx, sld, tld, func(hosts []DomainDNSHost) ([]DomainDNSHost, error) {
+		updated := make([]DomainDNSHost, 0, len(hosts)+1)
+		replaced := false
+		for _, h := range hosts {
+			if !strings.EqualFold(h.Name, host.Name) || !strings.EqualFold(string(h.Type), string(host.Type)) {
+				updated = append(updated, h)
+			} else if !replaced {
+				updated = append(updated, host)
+				replaced = true
+			}
+		}
+		if !replaced {
+			updated = append(updated, host)
+		}
+		return updated, nil
+	})
+}
+
+// ModifyHosts runs one read-modify-write cycle on the host records of a
+// domain: modify gets a copy of the current records and returns the list to
+// write. AddHost, UpdateHost, DeleteHost and UpsertHost are built on it, and
+// every cycle holds a per-domain lock shared by all clients in the process,
+// so concurrent edits in one process are applied one after the other.
+//
+// The write is skipped when modify leaves the records unchanged. Otherwise
+// the records are read again just before the write, which is abandoned with
+// a *HostConflictError if they changed since the first read, and read back
+// afterwards to check that the write took effect. Namecheap has no
+// conditional write, so a change made by another process between the second
+// read and the write can still be lost; it is then reported by the final
+// check when it left the records different. The domain's EmailType is kept.
+func (client *Client) ModifyHosts(sld, tld string, modify func([]DomainDNSHost) ([]DomainDNSHost, error)) (*DomainDNSGetHostsResult, error) {
+	return client.ModifyHostsContext(context.Background(), sld, tld, modify)
+}
+
+// ModifyHostsContext is like ModifyHosts but uses ctx for the underlying requests.
+func (client *Client) ModifyHostsContext(
+	ctx context.Context, sld, tld string,
+	modify func([]DomainDNSHost) ([]DomainDNSHost, error),
+) (*DomainDNSGetHostsResult, error) {
+	domain := strings.ToLower(sld + "." + tld)
+	unlock, err := hostLocks.lock(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	current, err := client.DomainsDNSGetHostsContext(ctx, sld, tld)
+	if err != nil {
+		return nil, err
+	}
+
+	original := append([]DomainDNSHost(nil), current.Hosts...)
+	want, err := modify(append([]DomainDNSHost(nil), current.Hosts...))
+	if err != nil {
+		return nil, err
+	}
+	if SameHosts(original, want) {
+		return current, nil
+	}
+
+	latest, err := client.DomainsDNSGetHostsContext(ctx, sld, tld)
+	if err != nil {
+		return nil, err
+	}
+	if !SameHosts(original, latest.Hosts) {
+		return latest, &HostConflictError{Domain: domain, Want: original, Got: latest.Hosts}
+	}
+
+	var options []DomainDNSSetHostsOption
+	if latest.EmailType != "" {
+		options = append(options, DomainDNSSetHostsOption{EmailType: latest.EmailType})
+	}
+	result, err := client.DomainDNSSetHostsContext(ctx, sld, tld, want, options...)
+	if err != nil {
+		return nil, err
+	}
+	if !result.IsSuccess {
+		return nil, fmt.Errorf("setting host records of %s was not successful", domain)
+	}
+
+	written, err := client.DomainsDNSGetHostsContext(ctx, sld, tld)
+	if err != nil {
+		return nil, fmt.Errorf("verifying host records of %s: %w", domain, err)
+	}
+	if !SameHosts(want, written.Hosts) {
+		return written, &HostConflictError{Domain: domain, Want: want, Got: written.Hosts}
+	}
+	return written, nil
+}
+
+// RecordKey identifies the record by name, type and address: records with
+// the same key are the same record, possibly with another TTL or MXPref.
+// Names and hostnames are compared case-insensitively and without a trailing
+// dot; TXT values as-is. HostId is left out since it changes on every
+// setHosts call.
+func (h DomainDNSHost) RecordKey() string {
+	typ := strings.ToUpper(string(h.Type))
+	addr := h.Address
+	if typ != "TXT" {
+		addr = strings.ToLower(strings.TrimSuffix(addr, "."))
+	}
+	return strings.Join([]string{strings.ToLower(h.Name), typ, addr}, "\x00")
+}
+
+// StateKey identifies the record by everything Namecheap stores for it: its
+// RecordKey, its EffectiveTTL and, for MX records only, MXPref, since
+// Namecheap reports a default MXPref for every other type.
+func (h DomainDNSHost) StateKey() string {
+	pref := ""
+	if strings.EqualFold(string(h.Type), "MX") {
+		pref = strconv.Itoa(h.MXPref)
+	}
+	return strings.Join([]string{h.RecordKey(), pref, strconv.Itoa(h.EffectiveTTL())}, "\x00")
+}
+
+// EffectiveTTL returns the TTL Namecheap stores for the record: TTL, or
+// DefaultHostTTL when it is unset.
+func (h DomainDNSHost) EffectiveTTL() int {
+	if h.TTL == 0 {
+		return DefaultHostTTL
+	}
+	return h.TTL
+}
+
+// SameHosts reports whether a and b hold the same records, compared by
+// StateKey, in any order.
+func SameHosts(a, b []DomainDNSHost) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := make(map[string]int, len(a))
+	for _, h := range a {
+		counts[h.StateKey()]++
+	}
+	for _, h := range b {
+		key := h.StateKey()
+		if counts[key] == 0 {
+			return false
+		}
+		counts[key]--
+	}
+	return true
+}
@@ -0,0 +1,296 @@
+package namecheap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeHosts serves getHosts and setHosts from an in-memory record list,
+// assigning fresh HostIds on every write like Namecheap does.
+type fakeHosts struct {
+	mu        sync.Mutex
+	hosts     []DomainDNSHost
+	emailType string
+	nextID    int
+	sets      int
+	// afterGet and afterSet, if set, run after each read and write to
+	// simulate a concurrent change.
+	afterGet func(f *fakeHosts)
+	afterSet func(f *fakeHosts)
+}
+
+func (f *fakeHosts) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch r.PostForm.Get("Command") {
+	case "namecheap.domains.dns.getHosts":
+		fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?>
+<ApiResponse xmlns="http://api.namecheap.com/xml.response" Status="OK">
+  <CommandResponse Type="namecheap.domains.dns.getHosts">
+    <DomainDNSGetHostsResult Domain="domain.com" EmailType="%s" IsUsingOurDNS="true">`, f.emailType)
+		for _, h := range f.hosts {
+			fmt.Fprintf(w, `<host HostId="%d" Name="%s" Type="%s" Address="%s" MXPref="%d" TTL="%d" />`,
+				h.ID, h.Name, h.Type, h.Address, h.MXPref, h.TTL)
+		}
+		fmt.Fprint(w, `</DomainDNSGetHostsResult></CommandResponse></ApiResponse>`)
+		if f.afterGet != nil {
+			f.afterGet(f)
+		}
+	case "namecheap.domains.dns.setHosts":
+		f.sets++
+		f.emailType = r.PostForm.Get("EmailType")
+		f.hosts = nil
+		for i := 1; r.PostForm.Get(fmt.Sprintf("HostName%d", i)) != ""; i++ {
+			f.nextID++
+			pref, _ := strconv.Atoi(r.PostForm.Get(fmt.Sprintf("MXPref%d", i)))
+			ttl, _ := strconv.Atoi(r.PostForm.Get(fmt.Sprintf("TTL%d", i)))
+			if ttl == 0 {
+				ttl = DefaultHostTTL
+			}
+			f.hosts = append(f.hosts, DomainDNSHost{
+				ID:      f.nextID,
+				Name:    r.PostForm.Get(fmt.Sprintf("HostName%d", i)),
+				Type:    RecordType(r.PostForm.Get(fmt.Sprintf("RecordType%d", i))),
+				Address: r.PostForm.Get(fmt.Sprintf("Address%d", i)),
+				MXPref:  pref,
+				TTL:     ttl,
+			})
+		}
+		if f.afterSet != nil {
+			f.afterSet(f)
+		}
+		fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8"?>
+<ApiResponse xmlns="http://api.namecheap.com/xml.response" Status="OK">
+  <CommandResponse Type="namecheap.domains.dns.setHosts">
+    <DomainDNSSetHostsResult Domain="domain.com" IsSuccess="true" />
+  </CommandResponse>
+</ApiResponse>`)
+	default:
+		http.Error(w, "unexpected command", http.StatusBadRequest)
+	}
+}
+
+func newFakeHosts() *fakeHosts {
+	return &fakeHosts{
+		nextID:    2,
+		emailType: "FWD",
+		hosts: []DomainDNSHost{
+			{ID: 1, Name: "@", Type: "A", Address: "1.2.3.4", MXPref: 10, TTL: 1800},
+			{ID: 2, Name: "www", Type: "CNAME", Address: "domain.com.", MXPref: 10, TTL: 1800},
+		},
+	}
+}
+
+func TestAddHost(t *testing.T) {
+	setup()
+	defer teardown()
+
+	fake := newFakeHosts()
+	mux.Handle("/", fake)
+
+	challenge := DomainDNSHost{Name: "_acme-challenge", Type: "TXT", Address: "token"}
+	result, err := client.AddHost("domain", "com", challenge)
+	if err != nil {
+		t.Fatalf("AddHost returned error: %v", err)
+	}
+	if len(result.Hosts) != 3 || fake.sets != 1 {
+		t.Errorf("AddHost left %d hosts after %d writes, want 3 after 1", len(result.Hosts), fake.sets)
+	}
+	if fake.emailType != "FWD" {
+		t.Errorf("AddHost changed EmailType to %q, want FWD", fake.emailType)
+	}
+
+	if _, err := client.AddHost("domain", "com", challenge); err != nil {
+		t.Fatalf("AddHost returned error: %v", err)
+	}
+	if fake.sets != 1 {
+		t.Errorf("adding an existing record should not write, got %d writes", fake.sets)
+	}
+
+	challenge.TTL = 60
+	result, err = client.AddHost("domain", "com", challenge)
+	if err != nil {
+		t.Fatalf("AddHost returned error: %v", err)
+	}
+	if len(result.Hosts) != 3 || result.Hosts[2].TTL != 60 {
+		t.Errorf("AddHost of an existing record with another TTL returned %+v, want its TTL updated", result.Hosts)
+	}
+}
+
+func TestUpdateAndDeleteHost(t *testing.T) {
+	setup()
+	defer teardown()
+
+	fake := newFakeHosts()
+	mux.Handle("/", fake)
+
+	result, err := client.UpdateHost("domain", "com", DomainDNSHost{ID: 1, Name: "@", Type: "A", Address: "5.6.7.8"})
+	if err != nil {
+		t.Fatalf("UpdateHost returned error: %v", err)
+	}
+	if got := result.Hosts[0].Address; got != "5.6.7.8" {
+		t.Errorf("UpdateHost left address %q, want 5.6.7.8", got)
+	}
+
+	if _, err := client.UpdateHost("domain", "com", DomainDNSHost{ID: 99, Name: "@", Type: "A"}); !errors.Is(err, ErrHostNotFound) {
+		t.Errorf("UpdateHost of unknown id returned %v, want ErrHostNotFound", err)
+	}
+
+	result, err = client.DeleteHost("domain", "com", DomainDNSHost{Name: "WWW", Type: "CNAME", Address: "domain.com"})
+	if err != nil {
+		t.Fatalf("DeleteHost returned error: %v", err)
+	}
+	if len(result.Hosts) != 1 {
+		t.Errorf("DeleteHost left %d hosts, want 1", len(result.Hosts))
+	}
+
+	if _, err := client.DeleteHost("domain", "com", DomainDNSHost{ID: 2}); !errors.Is(err, ErrHostNotFound) {
+		t.Errorf("DeleteHost of deleted record returned %v, want ErrHostNotFound", err)
+	}
+}
+
+func TestUpsertHost(t *testing.T) {
+	setup()
+	defer teardown()
+
+	fake := newFakeHosts()
+	mux.Handle("/", fake)
+
+	result, err := client.UpsertHost("domain", "com", DomainDNSHost{Name: "www", Type: "CNAME", Address: "other.com", TTL: 300})
+	if err != nil {
+		t.Fatalf("UpsertHost returned error: %v", err)
+	}
+	if len(result.Hosts) != 2 || result.Hosts[1].Address != "other.com" || result.Hosts[1].TTL != 300 {
+		t.Errorf("UpsertHost returned %+v, want www replaced", result.Hosts)
+	}
+
+	result, err = client.UpsertHost("domain", "com", DomainDNSHost{Name: "mail", Type: "A", Address: "9.9.9.9"})
+	if err != nil {
+		t.Fatalf("UpsertHost returned error: %v", err)
+	}
+	if len(result.Hosts) != 3 {
+		t.Errorf("UpsertHost of a new name left %d hosts, want 3", len(result.Hosts))
+	}
+}
+
+func TestModifyHostsConflict(t *testing.T) {
+	setup()
+	defer teardown()
+
+	fake := newFakeHosts()
+	fake.afterSet = func(f *fakeHosts) {
+		f.hosts = f.hosts[1:]
+	}
+	mux.Handle("/", fake)
+
+	_, err := client.AddHost("domain", "com", DomainDNSHost{Name: "api", Type: "A", Address: "1.1.1.1"})
+	if !errors.Is(err, ErrHostConflict) {
+		t.Fatalf("AddHost returned %v, want ErrHostConflict", err)
+	}
+	var conflict *HostConflictError
+	if !errors.As(err, &conflict) || len(conflict.Want) != 3 || len(conflict.Got) != 2 {
+		t.Errorf("AddHost returned %#v, want a HostConflictError with 3 wanted and 2 read back", err)
+	}
+}
+
+func TestModifyHostsChangedBeforeWrite(t *testing.T) {
+	setup()
+	defer teardown()
+
+	fake := newFakeHosts()
+	other := DomainDNSHost{ID: 99, Name: "other", Type: "A", Address: "2.2.2.2", MXPref: 10, TTL: 1800}
+	fake.afterGet = func(f *fakeHosts) {
+		f.hosts = append(f.hosts, other)
+		f.afterGet = nil
+	}
+	mux.Handle("/", fake)
+
+	_, err := client.AddHost("domain", "com", DomainDNSHost{Name: "api", Type: "A", Address: "1.1.1.1"})
+	var conflict *HostConflictError
+	if !errors.As(err, &conflict) || len(conflict.Want) != 2 || len(conflict.Got) != 3 {
+		t.Fatalf("AddHost returned %v, want a HostConflictError with 2 expected and 3 read", err)
+	}
+	if fake.sets != 0 {
+		t.Errorf("AddHost wrote %d times over a concurrent change, want 0", fake.sets)
+	}
+	if len(fake.hosts) != 3 || fake.hosts[2] != other {
+		t.Errorf("concurrent change was lost: %+v", fake.hosts)
+	}
+}
+
+func TestHostLocksReleased(t *testing.T) {
+	locks := &domainLocks{locks: map[string]*domainLock{}}
+	ctx := context.Background()
+
+	unlock, err := locks.lock(ctx, "domain.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := locks.lock(canceled, "domain.com"); !errors.Is(err, context.Canceled) {
+		t.Errorf("lock of a held domain returned %v, want %v", err, context.Canceled)
+	}
+
+	acquired := make(chan func())
+	go func() {
+		unlock, _ := locks.lock(ctx, "domain.com")
+		acquired <- unlock
+	}()
+	for {
+		locks.mu.Lock()
+		refs := locks.locks["domain.com"].refs
+		locks.mu.Unlock()
+		if refs == 2 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	unlock()
+	(<-acquired)()
+
+	if len(locks.locks) != 0 {
+		t.Errorf("%d lock entries left after every holder released them, want 0", len(locks.locks))
+	}
+}
+
+func TestAddHostConcurrent(t *testing.T) {
+	setup()
+	defer teardown()
+
+	fake := newFakeHosts()
+	mux.Handle("/", fake)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			host := DomainDNSHost{Name: "_acme-challenge", Type: "TXT", Address: fmt.Sprintf("token-%d", i)}
+			if _, err := client.AddHost("domain", "com", host); err != nil {
+				t.Errorf("AddHost returned error: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if len(fake.hosts) != 12 {
+		t.Errorf("concurrent AddHost left %d hosts, want 12", len(fake.hosts))
+	}
+	hostLocks.mu.Lock()
+	defer hostLocks.mu.Unlock()
+	if len(hostLocks.locks) != 0 {
+		t.Errorf("%d host lock entries left, want 0", len(hostLocks.locks))
+	}
+}
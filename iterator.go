@@ -0,0 +1,118 @@
+package namecheap
+
+import "context"
+
+// DomainIterator walks the domains of an account one page at a time, so that
+// only a single page is held in memory. Use it like bufio.Scanner:
+//
+//	it := client.IterateDomains(namecheap.DomainListFilter{})
+//	for it.Next() {
+//		fmt.Println(it.Domain().Name)
+//	}
+//	if err := it.Err(); err != nil {
+//		// Resume later with DomainListFilter{Page: it.NextPage()}.
+//	}
+type DomainIterator struct {
+	ctx    context.Context
+	client *Client
+	filter DomainListFilter
+
+	domains  []DomainGetListResult
+	index    int
+	page     uint
+	nextPage uint
+	total    uint
+	last     bool
+	err      error
+}
+
+// IterateDomains returns an iterator over the domains matching filter,
+// starting at filter.Page. Pages hold 100 domains unless filter.PageSize is
+// set.
+func (client *Client) IterateDomains(filter DomainListFilter) *DomainIterator {
+	return client.IterateDomainsContext(context.Background(), filter)
+}
+
+// IterateDomainsContext is like IterateDomains but uses ctx for the underlying requests.
+func (client *Client) IterateDomainsContext(ctx context.Context, filter DomainListFilter) *DomainIterator {
+	if filter.Page == 0 {
+		filter.Page = minCurrentPage
+	}
+	if filter.PageSize == 0 {
+		filter.PageSize = maxPerPage
+	}
+	return &DomainIterator{
+		ctx:      ctx,
+		client:   client,
+		filter:   filter,
+		nextPage: filter.Page,
+	}
+}
+
+// Next advances to the next domain, fetching the next page when the current
+// one is used up. It returns false once every domain up to TotalItems has
+// been seen or a request fails; check Err to tell the two apart.
+func (it *DomainIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	for it.index >= len(it.domains) {
+		if it.last {
+			return false
+		}
+		if !it.fetch() {
+			return false
+		}
+	}
+	it.index++
+	return true
+}
+
+func (it *DomainIterator) fetch() bool {
+	filter := it.filter
+	filter.Page = it.nextPage
+	r, err := it.client.domainsList(it.ctx, filter)
+	if err != nil {
+		it.err = err
+		return false
+	}
+
+	it.domains, it.index = r.Domains, 0
+	it.page, it.total = it.nextPage, r.TotalItems
+	it.nextPage++
+	seen := (it.page-1)*filter.PageSize + uint(len(r.Domains))
+	it.last = len(r.Domains) == 0 || seen >= it.total || it.nextPage > maxCurrentPage
+	return true
+}
+
+// Domain returns the domain Next advanced to.
+func (it *DomainIterator) Domain() DomainGetListResult {
+	if it.index == 0 {
+		return DomainGetListResult{}
+	}
+	return it.domains[it.index-1]
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *DomainIterator) Err() error {
+	return it.err
+}
+
+// Page returns the page the current domain came from, or 0 before the first
+// page has been fetched.
+func (it *DomainIterator) Page() uint {
+	return it.page
+}
+
+// NextPage returns the page the iterator fetches next. After a failure it is
+// the page that could not be fetched, so passing it as DomainListFilter.Page
+// resumes where the iteration stopped.
+func (it *DomainIterator) NextPage() uint {
+	return it.nextPage
+}
+
+// TotalItems returns the number of matching domains reported by the last
+// page fetched.
+func (it *DomainIterator) TotalItems() uint {
+	return it.total
+}
@@ -0,0 +1,159 @@
+package namecheap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// pagedDomains serves domains.getList for total domains named domainN.com,
+// failing with a 500 for the page in failPage.
+type pagedDomains struct {
+	mu       sync.Mutex
+	total    int
+	failPage int
+	requests []int
+}
+
+func (p *pagedDomains) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	page, _ := strconv.Atoi(r.PostForm.Get("page"))
+	pageSize, _ := strconv.Atoi(r.PostForm.Get("pageSize"))
+
+	p.mu.Lock()
+	p.requests = append(p.requests, page)
+	fail := page == p.failPage
+	p.mu.Unlock()
+	if fail {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	fmt.Fprint(w, `<?xml version="1.0" encoding="utf-8"?>
+<ApiResponse Status="OK" xmlns="http://api.namecheap.com/xml.response">
+  <CommandResponse Type="namecheap.domains.getList">
+    <DomainGetListResult>`)
+	for i := (page-1)*pageSize + 1; i <= page*pageSize && i <= p.total; i++ {
+		fmt.Fprintf(w, `<Domain ID="%d" Name="domain%d.com" />`, i, i)
+	}
+	fmt.Fprintf(w, `</DomainGetListResult>
+    <Paging>
+      <TotalItems>%d</TotalItems>
+      <CurrentPage>%d</CurrentPage>
+      <PageSize>%d</PageSize>
+    </Paging>
+  </CommandResponse>
+</ApiResponse>`, p.total, page, pageSize)
+}
+
+func TestDomainIterator(t *testing.T) {
+	setup()
+	defer teardown()
+
+	paged := &pagedDomains{total: 25}
+	mux.Handle("/", paged)
+
+	it := client.IterateDomains(DomainListFilter{PageSize: 10})
+	n := 0
+	for it.Next() {
+		n++
+		if want := fmt.Sprintf("domain%d.com", n); it.Domain().Name != want {
+			t.Errorf("domain %d is %q, want %q", n, it.Domain().Name, want)
+		}
+		if want := uint((n-1)/10 + 1); it.Page() != want {
+			t.Errorf("domain %d came from page %d, want %d", n, it.Page(), want)
+		}
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("iteration failed: %v", err)
+	}
+	if n != 25 || it.TotalItems() != 25 {
+		t.Errorf("iterated %d of %d domains, want 25", n, it.TotalItems())
+	}
+	if len(paged.requests) != 3 {
+		t.Errorf("fetched pages %v, want 3 pages", paged.requests)
+	}
+}
+
+func TestDomainIteratorStopsAtTotalItems(t *testing.T) {
+	setup()
+	defer teardown()
+
+	paged := &pagedDomains{total: 20}
+	mux.Handle("/", paged)
+
+	it := client.IterateDomains(DomainListFilter{PageSize: 10})
+	for it.Next() {
+	}
+	if it.Err() != nil {
+		t.Fatalf("iteration failed: %v", it.Err())
+	}
+	if len(paged.requests) != 2 {
+		t.Errorf("fetched pages %v, want no request past the last full page", paged.requests)
+	}
+}
+
+func TestDomainIteratorResume(t *testing.T) {
+	setup()
+	defer teardown()
+
+	paged := &pagedDomains{total: 35, failPage: 3}
+	mux.Handle("/", paged)
+
+	it := client.IterateDomains(DomainListFilter{PageSize: 10})
+	var names []string
+	for it.Next() {
+		names = append(names, it.Domain().Name)
+	}
+	var httpErr *HTTPError
+	if !errors.As(it.Err(), &httpErr) {
+		t.Fatalf("iteration returned %v, want an HTTPError", it.Err())
+	}
+	if len(names) != 20 || it.NextPage() != 3 {
+		t.Fatalf("stopped after %d domains at page %d, want 20 and page 3", len(names), it.NextPage())
+	}
+	if it.Next() {
+		t.Error("Next returned true after a failure")
+	}
+
+	paged.failPage = 0
+	it = client.IterateDomainsContext(context.Background(), DomainListFilter{PageSize: 10, Page: it.NextPage()})
+	for it.Next() {
+		names = append(names, it.Domain().Name)
+	}
+	if it.Err() != nil {
+		t.Fatalf("resumed iteration failed: %v", it.Err())
+	}
+	if len(names) != 35 || names[20] != "domain21.com" {
+		t.Errorf("resumed iteration gave %d domains starting at %q", len(names), names[20])
+	}
+}
+
+func TestDomainsGetCompleteList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	paged := &pagedDomains{total: 250, failPage: 3}
+	mux.Handle("/", paged)
+
+	domains, err := client.DomainsGetCompleteList()
+	if err == nil {
+		t.Error("DomainsGetCompleteList should have returned the page 3 error")
+	}
+	if len(domains) != 200 {
+		t.Errorf("DomainsGetCompleteList returned %d domains before failing, want 200", len(domains))
+	}
+
+	paged.failPage = 0
+	domains, err = client.DomainsGetCompleteList()
+	if err != nil || len(domains) != 250 {
+		t.Errorf("DomainsGetCompleteList returned %d domains, %v, want 250", len(domains), err)
+	}
+}
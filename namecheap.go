@@ -4,6 +4,7 @@
 package namecheap
 
 import (
+	"context"
 	"encoding/xml"
 	"errors"
 	"fmt"
@@ -23,6 +24,7 @@ const (
 	// https://www.namecheap.com/support/api/methods/domains/get-list.aspx
 	minPerPage     = 10
 	maxPerPage     = 100
+	defaultPerPage = 20
 	minCurrentPage = 1
 	maxCurrentPage = 999
 )
@@ -44,28 +46,6 @@ const (
 //                                      (End-user IP address)
 //
 
-// Namecheap API Global Error Codes
-// https://www.namecheap.com/support/api/global-parameters.aspx
-
-// A map may be the best datatype to store this information so it can be easily printed
-// for the user
-
-//1010101 		Parameter APIUser is missing
-//1030408 		Unsupported authentication type
-//1010104 		Parameter Command is missing
-//1010102, 1011102 	Parameter APIKey is missing
-//1010105, 1011105 	Parameter ClientIP is missing
-//1050900 		Unknown error when validating APIUser
-//1011150 		Parameter RequestIP is invalid
-//1017150 		Parameter RequestIP is disabled or locked
-//1017105 		Parameter ClientIP is disabled or locked
-//1017101 		Parameter ApiUser is disabled or locked
-//1017410 		Too many declined payments
-//1017411 		Too many login attempts
-//1019103 		Parameter UserName is not available
-//1016103 		Parameter UserName is unauthorized
-//1017103 		Parameter UserName is disabled or locked
-
 // Client represents a client used to make calls to the Namecheap API.
 type Client struct {
 	ApiUser    string
@@ -73,12 +53,36 @@ type Client struct {
 	UserName   string
 	HttpClient *http.Client
 
-	// Base URL for API requests.
-	// Defaults to the public Namecheap API,
-	// but can be set to a different endpoint (e.g. the sandbox).
-	// BaseURL should always be specified with a trailing slash.
+	// Base URL for API requests, including the /xml.response path.
+	// Defaults to the public Namecheap API, but can be set to a different
+	// endpoint; use SetEnvironment or NewSandboxClient for the sandbox.
 	BaseURL string
 
+	// AllowProductionSpending must be set before commands that charge the
+	// account (such as DomainCreate, DomainRenew and WhoisguardRenew) will
+	// run against any endpoint other than the sandbox. See IsProduction.
+	AllowProductionSpending bool
+
+	// RetryPolicy controls retries of failed requests. A nil policy sends
+	// every request once.
+	RetryPolicy *RetryPolicy
+
+	// RateLimiter, if set, is consulted before every request, including
+	// retries. Share one limiter between clients acting for the same user.
+	RateLimiter *RateLimiter
+
+	// ClientIP is sent as the ClientIp parameter on every request. Namecheap
+	// uses it to attribute calls to an end-user; it must be a valid IPv4 or
+	// IPv6 address. Use WithClientIP to override it for a single call.
+	// Defaults to 127.0.0.1 unless AutoDetectClientIP is set.
+	ClientIP string
+
+	// AutoDetectClientIP makes the client look up its public address at
+	// ClientIPLookupURL when ClientIP is empty. The answer is cached.
+	AutoDetectClientIP bool
+	ClientIPLookupURL  string
+	ipCache            *clientIPCache
+
 	*Registrant
 }
 
@@ -89,26 +93,65 @@ type ApiRequest struct {
 }
 
 type ApiResponse struct {
-	Status             string                    `xml:"Status,attr"`
-	Command            string                    `xml:"RequestedCommand"`
-	TLDList            []TLDListResult           `xml:"CommandResponse>Tlds>Tld"`
-	Domains            []DomainGetListResult     `xml:"CommandResponse>DomainGetListResult>Domain"`
-	DomainInfo         *DomainInfo               `xml:"CommandResponse>DomainGetInfoResult"`
-	DomainDNSHosts     *DomainDNSGetHostsResult  `xml:"CommandResponse>DomainDNSGetHostsResult"`
-	DomainDNSSetHosts  *DomainDNSSetHostsResult  `xml:"CommandResponse>DomainDNSSetHostsResult"`
-	DomainCreate       *DomainCreateResult       `xml:"CommandResponse>DomainCreateResult"`
-	DomainRenew        *DomainRenewResult        `xml:"CommandResponse>DomainRenewResult"`
-	DomainsCheck       []DomainCheckResult       `xml:"CommandResponse>DomainCheckResult"`
-	DomainNSInfo       *DomainNSInfoResult       `xml:"CommandResponse>DomainNSInfoResult"`
-	DomainDNSSetCustom *DomainDNSSetCustomResult `xml:"CommandResponse>DomainDNSSetCustomResult"`
-	UsersGetPricing    []UsersGetPricingResult   `xml:"CommandResponse>UserGetPricingResult>ProductType"`
-	WhoisguardList     []WhoisguardGetListResult `xml:"CommandResponse>WhoisguardGetListResult>Whoisguard"`
-	WhoisguardEnable   whoisguardEnableResult    `xml:"CommandResponse>WhoisguardEnableResult"`
-	WhoisguardDisable  whoisguardDisableResult   `xml:"CommandResponse>WhoisguardDisableResult"`
-	WhoisguardRenew    *WhoisguardRenewResult    `xml:"CommandResponse>WhoisguardRenewResult"`
-	TotalItems         uint                      `xml:"CommandResponse>Paging>TotalItems"`
-	CurrentPage        uint                      `xml:"CommandResponse>Paging>CurrentPage"`
-	PageSize           uint                      `xml:"CommandResponse>Paging>PageSize"`
+	Status                     string                            `xml:"Status,attr"`
+	Command                    string                            `xml:"RequestedCommand"`
+	TLDList                    []TLDListResult                   `xml:"CommandResponse>Tlds>Tld"`
+	Domains                    []DomainGetListResult             `xml:"CommandResponse>DomainGetListResult>Domain"`
+	DomainInfo                 *DomainInfo                       `xml:"CommandResponse>DomainGetInfoResult"`
+	DomainDNSHosts             *DomainDNSGetHostsResult          `xml:"CommandResponse>DomainDNSGetHostsResult"`
+	DomainDNSSetHosts          *DomainDNSSetHostsResult          `xml:"CommandResponse>DomainDNSSetHostsResult"`
+	DomainCreate               *DomainCreateResult               `xml:"CommandResponse>DomainCreateResult"`
+	DomainRenew                *DomainRenewResult                `xml:"CommandResponse>DomainRenewResult"`
+	DomainsCheck               []DomainCheckResult               `xml:"CommandResponse>DomainCheckResult"`
+	DomainContacts             *DomainContactsResult             `xml:"CommandResponse>DomainContactsResult"`
+	DomainSetContacts          *DomainSetContactsResult          `xml:"CommandResponse>DomainSetContactResult"`
+	DomainGetRegistrarLock     *DomainGetRegistrarLockResult     `xml:"CommandResponse>DomainGetRegistrarLockResult"`
+	DomainSetRegistrarLock     *DomainSetRegistrarLockResult     `xml:"CommandResponse>DomainSetRegistrarLockResult"`
+	DomainNSInfo               *DomainNSInfoResult               `xml:"CommandResponse>DomainNSInfoResult"`
+	DomainNSCreate             *DomainNSCreateResult             `xml:"CommandResponse>DomainNSCreateResult"`
+	DomainNSDelete             *DomainNSDeleteResult             `xml:"CommandResponse>DomainNSDeleteResult"`
+	DomainNSUpdate             *DomainNSUpdateResult             `xml:"CommandResponse>DomainNSUpdateResult"`
+	DomainDNSSetCustom         *DomainDNSSetCustomResult         `xml:"CommandResponse>DomainDNSSetCustomResult"`
+	DomainDNSSetDefault        *DomainDNSSetDefaultResult        `xml:"CommandResponse>DomainDNSSetDefaultResult"`
+	DomainDNSList              *DomainDNSGetListResult           `xml:"CommandResponse>DomainDNSGetListResult"`
+	DomainEmailForwarding      *DomainDNSEmailForwardingResult   `xml:"CommandResponse>DomainEmailForwarding"`
+	DomainSetEmailForwarding   *DomainDNSSetEmailForwardResult   `xml:"CommandResponse>DomainEmailForwardingResult"`
+	DomainTransferCreate       *DomainTransferCreateResult       `xml:"CommandResponse>DomainTransferCreateResult"`
+	DomainTransferGetStatus    *DomainTransferGetStatusResult    `xml:"CommandResponse>DomainTransferGetStatusResult"`
+	DomainTransferUpdateStatus *DomainTransferUpdateStatusResult `xml:"CommandResponse>DomainTransferUpdateStatusResult"`
+	Transfers                  []TransferGetListResult           `xml:"CommandResponse>TransferGetListResult>Transfer"`
+	SSLCreate                  *SSLCreateResult                  `xml:"CommandResponse>SSLCreateResult"`
+	SSLList                    []SSLGetListResult                `xml:"CommandResponse>SSLListResult>SSL"`
+	SSLInfo                    *SSLInfo                          `xml:"CommandResponse>SSLGetInfoResult"`
+	SSLActivate                *SSLActivateResult                `xml:"CommandResponse>SSLActivateResult"`
+	SSLReissue                 *SSLActivateResult                `xml:"CommandResponse>SSLReissueResult"`
+	SSLParseCSR                *SSLParseCSRResult                `xml:"CommandResponse>SSLParseCSRResult"`
+	SSLApproverEmailList       *SSLApproverEmailListResult       `xml:"CommandResponse>GetApproverEmailListResult"`
+	SSLResendApproverEmail     *SSLResendApproverEmailResult     `xml:"CommandResponse>SSLResendApproverEmailResult"`
+	SSLRenew                   *SSLRenewResult                   `xml:"CommandResponse>SSLRenewResult"`
+	SSLRevoke                  *SSLRevokeResult                  `xml:"CommandResponse>RevokeCertificateResult"`
+	UsersGetPricing            []UsersGetPricingResult           `xml:"CommandResponse>UserGetPricingResult>ProductType"`
+	UsersGetBalances           *UsersGetBalancesResult           `xml:"CommandResponse>UserGetBalancesResult"`
+	UsersCreateAddFundsRequest *UsersCreateAddFundsRequestResult `xml:"CommandResponse>Createaddfundsrequestresult"`
+	UsersGetAddFundsStatus     *UsersGetAddFundsStatusResult     `xml:"CommandResponse>GetAddFundsStatusResult"`
+	UsersAddressCreate         *UserAddressResult                `xml:"CommandResponse>AddressCreateResult"`
+	UsersAddressDelete         *UserAddressDeleteResult          `xml:"CommandResponse>AddressDeleteResult"`
+	UsersAddressInfo           *UserAddress                      `xml:"CommandResponse>GetAddressInfoResult"`
+	UsersAddressList           []UserAddressListEntry            `xml:"CommandResponse>AddressGetListResult>List"`
+	UsersAddressSetDefault     *UserAddressResult                `xml:"CommandResponse>AddressSetDefaultResult"`
+	UsersAddressUpdate         *UserAddressResult                `xml:"CommandResponse>AddressUpdateResult"`
+	UsersCreate                *UsersResult                      `xml:"CommandResponse>UserCreateResult"`
+	UsersUpdate                *UsersResult                      `xml:"CommandResponse>UserUpdateResult"`
+	UsersChangePassword        *UsersResult                      `xml:"CommandResponse>UserChangePasswordResult"`
+	UsersResetPassword         *UsersResetPasswordResult         `xml:"CommandResponse>UserResetPasswordResult"`
+	UsersLogin                 *UsersLoginResult                 `xml:"CommandResponse>UserLoginResult"`
+	WhoisguardList             []WhoisguardGetListResult         `xml:"CommandResponse>WhoisguardGetListResult>Whoisguard"`
+	WhoisguardEnable           whoisguardEnableResult            `xml:"CommandResponse>WhoisguardEnableResult"`
+	WhoisguardDisable          whoisguardDisableResult           `xml:"CommandResponse>WhoisguardDisableResult"`
+	WhoisguardRenew            *WhoisguardRenewResult            `xml:"CommandResponse>WhoisguardRenewResult"`
+	TotalItems                 uint                              `xml:"CommandResponse>Paging>TotalItems"`
+	CurrentPage                uint                              `xml:"CommandResponse>Paging>CurrentPage"`
+	PageSize                   uint                              `xml:"CommandResponse>Paging>PageSize"`
 
 	Errors ApiErrors `xml:"Errors>Error"`
 }
@@ -162,30 +205,39 @@ func ValidateCurrentPage(page uint) uint {
 	return page
 }
 
+// ValidateSearchTerm drops search terms shorter than 2 characters, truncates
+// those longer than 128, and returns an error if the term contains characters
+// that cannot appear in a domain name.
 func ValidateSearchTerm(searchTerm string) (string, error) {
 	if len(searchTerm) <= 1 {
 		searchTerm = ""
 	} else if len(searchTerm) >= 128 {
 		searchTerm = searchTerm[:128]
 	}
-	if searchTerm != "" && ValidDomainName(searchTerm) {
+	if searchTerm != "" && !ValidDomainName(strings.ReplaceAll(searchTerm, ".", "")) {
 		return searchTerm, errors.New("invalid domain characters in search term")
 	}
 	return searchTerm, nil
 }
 
+// ValidateListType returns listType in upper case if it is ALL, EXPIRING or
+// EXPIRED, and ALL otherwise.
 func ValidateListType(listType string) string {
-	if listType != ALL || listType != EXPIRING || listType != EXPIRED {
-		listType = ALL
+	switch listType = strings.ToUpper(listType); listType {
+	case ALL, EXPIRING, EXPIRED:
+		return listType
 	}
-	return listType
+	return ALL
 }
 
+// ValidateSortBy returns sortBy in upper case if it is one of the SortBy
+// values accepted by domains.getList, and NAME_ASC otherwise.
 func ValidateSortBy(sortBy string) string {
-	if sortBy != NAME_ASC || sortBy != NAME_DESC || sortBy != EXPIRE_DATE_ASC || sortBy != EXPIRE_DATE_DESC || sortBy != CREATE_DATE_ASC || sortBy != CREATE_DATE_DESC {
-		sortBy = NAME_ASC
+	switch sortBy = strings.ToUpper(sortBy); sortBy {
+	case NAME_ASC, NAME_DESC, EXPIRE_DATE_ASC, EXPIRE_DATE_DESC, CREATE_DATE_ASC, CREATE_DATE_DESC:
+		return sortBy
 	}
-	return sortBy
+	return NAME_ASC
 }
 
 // API CLIENT
@@ -196,6 +248,7 @@ func NewClient(apiUser, apiToken, userName string) *Client {
 		UserName:   userName,
 		HttpClient: http.DefaultClient,
 		BaseURL:    defaultBaseURL,
+		ipCache:    &clientIPCache{},
 	}
 }
 
@@ -214,15 +267,36 @@ func (client *Client) NewRegistrant(
 	)
 }
 
-func (client *Client) do(request *ApiRequest) (*ApiResponse, error) {
+func (client *Client) do(ctx context.Context, request *ApiRequest) (*ApiResponse, error) {
 	if request.method == "" {
 		return nil, errors.New("request method cannot be blank")
 	}
 
-	body, _, err := client.sendRequest(request)
+	for attempt := 1; ; attempt++ {
+		resp, err := client.doOnce(ctx, request)
+		if err == nil || !client.RetryPolicy.shouldRetry(request, attempt, err) {
+			return resp, err
+		}
+		if err := client.RetryPolicy.wait(ctx, attempt); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (client *Client) doOnce(ctx context.Context, request *ApiRequest) (*ApiResponse, error) {
+	if client.RateLimiter != nil {
+		if err := client.RateLimiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	body, statusCode, err := client.sendRequest(ctx, request)
 	if err != nil {
 		return nil, err
 	}
+	if statusCode < 200 || statusCode > 299 {
+		return nil, &HTTPError{StatusCode: statusCode}
+	}
 
 	resp := new(ApiResponse)
 	if err = xml.Unmarshal(body, resp); err != nil {
@@ -236,17 +310,21 @@ func (client *Client) do(request *ApiRequest) (*ApiResponse, error) {
 	return resp, nil
 }
 
-func (client *Client) makeRequest(request *ApiRequest) (*http.Request, error) {
+func (client *Client) makeRequest(ctx context.Context, request *ApiRequest) (*http.Request, error) {
+	clientIP, err := client.clientIP(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	p := request.params
 	p.Set("ApiUser", client.ApiUser)
 	p.Set("ApiKey", client.ApiToken)
-	p.Set("UserName", client.UserName)
-	// This param is required by the API, but not actually used.
-	p.Set("ClientIp", "127.0.0.1")
+	p.Set("UserName", client.userName(ctx))
+	p.Set("ClientIp", clientIP)
 	p.Set("Command", request.command)
 
 	b := p.Encode()
-	req, err := http.NewRequest(request.method, client.BaseURL, strings.NewReader(b))
+	req, err := http.NewRequestWithContext(ctx, request.method, client.BaseURL, strings.NewReader(b))
 	if err != nil {
 		return nil, err
 	}
@@ -255,8 +333,8 @@ func (client *Client) makeRequest(request *ApiRequest) (*http.Request, error) {
 	return req, nil
 }
 
-func (client *Client) sendRequest(request *ApiRequest) ([]byte, int, error) {
-	req, err := client.makeRequest(request)
+func (client *Client) sendRequest(ctx context.Context, request *ApiRequest) ([]byte, int, error) {
+	req, err := client.makeRequest(ctx, request)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -276,41 +354,23 @@ func (client *Client) sendRequest(request *ApiRequest) ([]byte, int, error) {
 }
 
 func (client *Client) DomainsListAPIRequest(page uint, pageSize uint, searchTerm, listType, sortBy string) (*ApiResponse, error) {
-	// VALIDATION
-	// [pageSize] must be equal or GREATER than 10
-	// [pageSize] must be equal or LESS than 100
-	pageSize = ValidatePageSize(pageSize)
-	// [page] must be equal or GREATER than 1
-	// [page] must be qual or LESS than 999 (sanity)
-	page = ValidateCurrentPage(page)
-	// [searchTerm] must be alphanumeric
-	// [searchTerm] must have a length GREATER than 1
-	// [searchTerm] must have a length LESS than 128
-	searchTerm, err := ValidateSearchTerm(searchTerm)
-	if err != nil {
-		return nil, err
-	}
-	// [listType] can only be ALL, EXPIRING, or EXPIRED (Default: ALL)
-	listType = ValidateListType(listType)
-	// [sortBy] can only be NAME, NAME_DESC, EXPIREDATE, EXPIREDATE_DESC, CREATEDATE, CREATEDATE_DESC
-	sortBy = ValidateSortBy(sortBy)
-
-	requestInfo := &ApiRequest{
-		command: domainsGetList,
-		method:  "POST",
-		params:  url.Values{},
-	}
-	requestInfo.params.Set("page", strconv.Itoa(int(page)))
-	requestInfo.params.Set("pageSize", strconv.Itoa(int(pageSize)))
+	return client.DomainsListAPIRequestContext(context.Background(), page, pageSize, searchTerm, listType, sortBy)
+}
 
-	r, err := client.do(requestInfo)
-	if err != nil {
-		return nil, err
+// DomainsListAPIRequestContext is like DomainsListAPIRequest but uses ctx for the underlying request.
+// Unknown list types and sort orders fall back to ALL and NAME_ASC; use
+// DomainsList to have them reported as errors instead.
+func (client *Client) DomainsListAPIRequestContext(ctx context.Context, page uint, pageSize uint, searchTerm, listType, sortBy string) (*ApiResponse, error) {
+	filter := DomainListFilter{
+		SearchTerm: searchTerm,
+		Page:       ValidateCurrentPage(page),
+		PageSize:   ValidatePageSize(pageSize),
+	}
+	if listType != "" {
+		filter.ListType = ValidateListType(listType)
 	}
-	if &r == nil {
-		return nil, errors.New("Request struct failed to be assigned")
-	} else if &r.TotalItems == nil {
-		return nil, errors.New("Request fields related to Paging, specifically 'TotalItems', 'CurrentPage', and 'PageSize' failed to be assigned")
+	if sortBy != "" {
+		filter.SortBy = ValidateSortBy(sortBy)
 	}
-	return r, nil
+	return client.domainsList(ctx, filter)
 }
@@ -1,11 +1,16 @@
 package namecheap
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"path/filepath"
 	"testing"
+	"time"
 )
 
 var (
@@ -22,6 +27,8 @@ func setup() {
 
 	client = NewClient("anApiUser", "anToken", "anUser")
 	client.BaseURL = server.URL + "/"
+	// The test server is not the sandbox, so it counts as production.
+	client.AllowProductionSpending = true
 }
 
 func fillDefaultParams(p url.Values) url.Values {
@@ -53,6 +60,26 @@ func testBody(t *testing.T, r *http.Request, p url.Values) {
 	}
 }
 
+// readFixture returns the contents of a file in sample-api-responses.
+func readFixture(t *testing.T, name string) string {
+	b, err := ioutil.ReadFile(filepath.Join("sample-api-responses", name))
+	if err != nil {
+		t.Fatalf("Error reading fixture: %v", err)
+	}
+	return string(b)
+}
+
+// serveFixture answers every request with the named fixture after checking
+// that the request carried exactly the given params.
+func serveFixture(t *testing.T, name string, params url.Values) {
+	respXML := readFixture(t, name)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		testBody(t, r, fillDefaultParams(params))
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, respXML)
+	})
+}
+
 func TestNewClient(t *testing.T) {
 	c := NewClient("anApiUser", "anToken", "anUser")
 
@@ -70,7 +97,7 @@ func TestMakeRequest(t *testing.T) {
 		command: "namecheap.domains.getList",
 		params:  url.Values{},
 	}
-	req, _ := c.makeRequest(requestInfo)
+	req, _ := c.makeRequest(context.Background(), requestInfo)
 
 	// correctly assembled URL
 	outURL := "https://fake-api-server/"
@@ -83,3 +110,48 @@ func TestMakeRequest(t *testing.T) {
 	correctParams.Set("Command", "namecheap.domains.getList")
 	testBody(t, req, correctParams)
 }
+
+// slowHandler returns a handler that blocks until release is closed, so tests
+// can observe cancellation without waiting on a real timeout.
+func slowHandler(release chan struct{}) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}
+}
+
+func TestContextCanceled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	release := make(chan struct{})
+	defer close(release)
+	mux.HandleFunc("/", slowHandler(release))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+	}()
+
+	_, err := client.DomainGetInfoContext(ctx, "example.com")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("DomainGetInfoContext returned error %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestContextDeadlineExceeded(t *testing.T) {
+	setup()
+	defer teardown()
+
+	release := make(chan struct{})
+	defer close(release)
+	mux.HandleFunc("/", slowHandler(release))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := client.DomainsDNSGetHostsContext(ctx, "domain", "com")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("DomainsDNSGetHostsContext returned error %v, want %v", err, context.DeadlineExceeded)
+	}
+}
@@ -1,6 +1,9 @@
 package namecheap
 
-import "net/url"
+import (
+	"context"
+	"net/url"
+)
 
 const (
 	nsCreate  = "namecheap.domains.ns.create"
@@ -16,7 +19,34 @@ type DomainNSInfoResult struct {
 	Statuses   []string `xml:"NameserverStatuses>Status"`
 }
 
+// DomainNSCreateResult represents the data returned by 'domains.ns.create'
+type DomainNSCreateResult struct {
+	Domain     string `xml:"Domain,attr"`
+	Nameserver string `xml:"Nameserver,attr"`
+	IP         string `xml:"IP,attr"`
+	IsSuccess  bool   `xml:"IsSuccess,attr"`
+}
+
+// DomainNSDeleteResult represents the data returned by 'domains.ns.delete'
+type DomainNSDeleteResult struct {
+	Domain     string `xml:"Domain,attr"`
+	Nameserver string `xml:"Nameserver,attr"`
+	IsSuccess  bool   `xml:"IsSuccess,attr"`
+}
+
+// DomainNSUpdateResult represents the data returned by 'domains.ns.update'
+type DomainNSUpdateResult struct {
+	Domain     string `xml:"Domain,attr"`
+	Nameserver string `xml:"Nameserver,attr"`
+	IsSuccess  bool   `xml:"IsSuccess,attr"`
+}
+
 func (client *Client) NSGetInfo(sld, tld, nameserver string) (*DomainNSInfoResult, error) {
+	return client.NSGetInfoContext(context.Background(), sld, tld, nameserver)
+}
+
+// NSGetInfoContext is like NSGetInfo but uses ctx for the underlying request.
+func (client *Client) NSGetInfoContext(ctx context.Context, sld, tld, nameserver string) (*DomainNSInfoResult, error) {
 	requestInfo := &ApiRequest{
 		command: nsGetInfo,
 		method:  "POST",
@@ -26,10 +56,85 @@ func (client *Client) NSGetInfo(sld, tld, nameserver string) (*DomainNSInfoResul
 	requestInfo.params.Set("TLD", tld)
 	requestInfo.params.Set("Nameserver", nameserver)
 
-	resp, err := client.do(requestInfo)
+	resp, err := client.do(ctx, requestInfo)
 	if err != nil {
 		return nil, err
 	}
 
 	return resp.DomainNSInfo, nil
 }
+
+// NSCreate registers a new nameserver (glue record) under the given domain.
+func (client *Client) NSCreate(sld, tld, nameserver, ip string) (*DomainNSCreateResult, error) {
+	return client.NSCreateContext(context.Background(), sld, tld, nameserver, ip)
+}
+
+// NSCreateContext is like NSCreate but uses ctx for the underlying request.
+func (client *Client) NSCreateContext(ctx context.Context, sld, tld, nameserver, ip string) (*DomainNSCreateResult, error) {
+	requestInfo := &ApiRequest{
+		command: nsCreate,
+		method:  "POST",
+		params:  url.Values{},
+	}
+	requestInfo.params.Set("SLD", sld)
+	requestInfo.params.Set("TLD", tld)
+	requestInfo.params.Set("Nameserver", nameserver)
+	requestInfo.params.Set("IP", ip)
+
+	resp, err := client.do(ctx, requestInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.DomainNSCreate, nil
+}
+
+// NSDelete deletes a nameserver associated with the given domain.
+func (client *Client) NSDelete(sld, tld, nameserver string) (*DomainNSDeleteResult, error) {
+	return client.NSDeleteContext(context.Background(), sld, tld, nameserver)
+}
+
+// NSDeleteContext is like NSDelete but uses ctx for the underlying request.
+func (client *Client) NSDeleteContext(ctx context.Context, sld, tld, nameserver string) (*DomainNSDeleteResult, error) {
+	requestInfo := &ApiRequest{
+		command: nsDelete,
+		method:  "POST",
+		params:  url.Values{},
+	}
+	requestInfo.params.Set("SLD", sld)
+	requestInfo.params.Set("TLD", tld)
+	requestInfo.params.Set("Nameserver", nameserver)
+
+	resp, err := client.do(ctx, requestInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.DomainNSDelete, nil
+}
+
+// NSUpdate changes the IP address of a registered nameserver from oldIP to ip.
+func (client *Client) NSUpdate(sld, tld, nameserver, oldIP, ip string) (*DomainNSUpdateResult, error) {
+	return client.NSUpdateContext(context.Background(), sld, tld, nameserver, oldIP, ip)
+}
+
+// NSUpdateContext is like NSUpdate but uses ctx for the underlying request.
+func (client *Client) NSUpdateContext(ctx context.Context, sld, tld, nameserver, oldIP, ip string) (*DomainNSUpdateResult, error) {
+	requestInfo := &ApiRequest{
+		command: nsUpdate,
+		method:  "POST",
+		params:  url.Values{},
+	}
+	requestInfo.params.Set("SLD", sld)
+	requestInfo.params.Set("TLD", tld)
+	requestInfo.params.Set("Nameserver", nameserver)
+	requestInfo.params.Set("OldIP", oldIP)
+	requestInfo.params.Set("IP", ip)
+
+	resp, err := client.do(ctx, requestInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.DomainNSUpdate, nil
+}
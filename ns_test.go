@@ -55,3 +55,133 @@ func TestNSGetInfo(t *testing.T) {
 		t.Errorf("NSGetInfo returned %+v, want %+v", ns, want)
 	}
 }
+
+func TestNSCreate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	respXML := `
+<?xml version="1.0" encoding="UTF-8"?>
+<ApiResponse xmlns="http://api.namecheap.com/xml.response" Status="OK">
+  <Errors />
+  <RequestedCommand>namecheap.domains.ns.create</RequestedCommand>
+  <CommandResponse Type="namecheap.domains.ns.create">
+    <DomainNSCreateResult Domain="domain.com" Nameserver="ns1.domain.com" IP="12.23.23.23" IsSuccess="true" />
+  </CommandResponse>
+  <Server>SERVER-NAME</Server>
+  <GMTTimeDifference>+5</GMTTimeDifference>
+  <ExecutionTime>32.76</ExecutionTime>
+</ApiResponse>`
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		correctParams := fillDefaultParams(url.Values{})
+		correctParams.Set("Command", "namecheap.domains.ns.create")
+		correctParams.Set("Nameserver", "ns1.domain.com")
+		correctParams.Set("IP", "12.23.23.23")
+		correctParams.Set("SLD", "domain")
+		correctParams.Set("TLD", "com")
+		testBody(t, r, correctParams)
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, respXML)
+	})
+
+	result, err := client.NSCreate("domain", "com", "ns1.domain.com", "12.23.23.23")
+	if err != nil {
+		t.Errorf("NSCreate returned error: %v", err)
+	}
+	want := &DomainNSCreateResult{
+		Domain:     "domain.com",
+		Nameserver: "ns1.domain.com",
+		IP:         "12.23.23.23",
+		IsSuccess:  true,
+	}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("NSCreate returned %+v, want %+v", result, want)
+	}
+}
+
+func TestNSDelete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	respXML := `
+<?xml version="1.0" encoding="UTF-8"?>
+<ApiResponse xmlns="http://api.namecheap.com/xml.response" Status="OK">
+  <Errors />
+  <RequestedCommand>namecheap.domains.ns.delete</RequestedCommand>
+  <CommandResponse Type="namecheap.domains.ns.delete">
+    <DomainNSDeleteResult Domain="domain.com" Nameserver="ns1.domain.com" IsSuccess="true" />
+  </CommandResponse>
+  <Server>SERVER-NAME</Server>
+  <GMTTimeDifference>+5</GMTTimeDifference>
+  <ExecutionTime>32.76</ExecutionTime>
+</ApiResponse>`
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		correctParams := fillDefaultParams(url.Values{})
+		correctParams.Set("Command", "namecheap.domains.ns.delete")
+		correctParams.Set("Nameserver", "ns1.domain.com")
+		correctParams.Set("SLD", "domain")
+		correctParams.Set("TLD", "com")
+		testBody(t, r, correctParams)
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, respXML)
+	})
+
+	result, err := client.NSDelete("domain", "com", "ns1.domain.com")
+	if err != nil {
+		t.Errorf("NSDelete returned error: %v", err)
+	}
+	want := &DomainNSDeleteResult{
+		Domain:     "domain.com",
+		Nameserver: "ns1.domain.com",
+		IsSuccess:  true,
+	}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("NSDelete returned %+v, want %+v", result, want)
+	}
+}
+
+func TestNSUpdate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	respXML := `
+<?xml version="1.0" encoding="UTF-8"?>
+<ApiResponse xmlns="http://api.namecheap.com/xml.response" Status="OK">
+  <Errors />
+  <RequestedCommand>namecheap.domains.ns.update</RequestedCommand>
+  <CommandResponse Type="namecheap.domains.ns.update">
+    <DomainNSUpdateResult Domain="domain.com" Nameserver="ns1.domain.com" IsSuccess="true" />
+  </CommandResponse>
+  <Server>SERVER-NAME</Server>
+  <GMTTimeDifference>+5</GMTTimeDifference>
+  <ExecutionTime>32.76</ExecutionTime>
+</ApiResponse>`
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		correctParams := fillDefaultParams(url.Values{})
+		correctParams.Set("Command", "namecheap.domains.ns.update")
+		correctParams.Set("Nameserver", "ns1.domain.com")
+		correctParams.Set("OldIP", "12.23.23.23")
+		correctParams.Set("IP", "34.45.45.45")
+		correctParams.Set("SLD", "domain")
+		correctParams.Set("TLD", "com")
+		testBody(t, r, correctParams)
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, respXML)
+	})
+
+	result, err := client.NSUpdate("domain", "com", "ns1.domain.com", "12.23.23.23", "34.45.45.45")
+	if err != nil {
+		t.Errorf("NSUpdate returned error: %v", err)
+	}
+	want := &DomainNSUpdateResult{
+		Domain:     "domain.com",
+		Nameserver: "ns1.domain.com",
+		IsSuccess:  true,
+	}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("NSUpdate returned %+v, want %+v", result, want)
+	}
+}
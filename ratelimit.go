@@ -0,0 +1,192 @@
+package namecheap
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// RateLimit allows at most Limit calls in any sliding Window.
+type RateLimit struct {
+	Limit  int
+	Window time.Duration
+}
+
+// DefaultRateLimits are the per-user quotas of the Namecheap API, as listed
+// in the API FAQ.
+var DefaultRateLimits = []RateLimit{
+	{Limit: 20, Window: time.Minute},
+	{Limit: 700, Window: time.Hour},
+	{Limit: 8000, Window: 24 * time.Hour},
+}
+
+// RateLimitError is returned by a non-blocking RateLimiter when a call would
+// exceed one of its limits.
+type RateLimitError struct {
+	RateLimit
+	// RetryAfter is how long until the call would be allowed.
+	RetryAfter time.Duration
+}
+
+func (err *RateLimitError) Error() string {
+	return fmt.Sprintf(
+		"namecheap: client rate limit of %d calls per %v reached, retry after %v",
+		err.Limit, err.Window, err.RetryAfter,
+	)
+}
+
+// RateLimitBudget is the state of one window of a RateLimiter.
+type RateLimitBudget struct {
+	RateLimit
+	Remaining int
+	// ResetIn is how long until the oldest call in the window expires and
+	// frees up budget. It is zero when the window is empty.
+	ResetIn time.Duration
+}
+
+// RateLimiter enforces several sliding-window limits together. It is safe for
+// concurrent use, so one limiter can be shared by every client acting for the
+// same Namecheap user. The zero RateLimiter enforces DefaultRateLimits.
+type RateLimiter struct {
+	// Block makes Wait sleep until the call is allowed instead of returning
+	// a *RateLimitError.
+	Block bool
+
+	mu     sync.Mutex
+	limits []RateLimit
+	// calls holds the start time of every call within the longest window,
+	// oldest first.
+	calls []time.Time
+	now   func() time.Time
+}
+
+// NewRateLimiter returns a limiter enforcing limits, or DefaultRateLimits if
+// none are given. Limits with a non-positive Limit or Window are ignored.
+func NewRateLimiter(block bool, limits ...RateLimit) *RateLimiter {
+	var valid []RateLimit
+	for _, limit := range limits {
+		if limit.Limit > 0 && limit.Window > 0 {
+			valid = append(valid, limit)
+		}
+	}
+	return &RateLimiter{
+		Block:  block,
+		limits: valid,
+		now:    time.Now,
+	}
+}
+
+// rateLimits returns the limits to enforce.
+func (limiter *RateLimiter) rateLimits() []RateLimit {
+	if len(limiter.limits) == 0 {
+		return DefaultRateLimits
+	}
+	return limiter.limits
+}
+
+func (limiter *RateLimiter) clock() time.Time {
+	if limiter.now == nil {
+		return time.Now()
+	}
+	return limiter.now()
+}
+
+// Wait reserves one call, blocking or failing as configured when any limit
+// is exhausted.
+func (limiter *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		limit, delay := limiter.reserve()
+		if delay <= 0 {
+			return nil
+		}
+		if !limiter.Block {
+			return &RateLimitError{RateLimit: limit, RetryAfter: delay}
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Remaining reports the budget left in each window.
+func (limiter *RateLimiter) Remaining() []RateLimitBudget {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+
+	now := limiter.clock()
+	limiter.prune(now)
+	limits := limiter.rateLimits()
+	budgets := make([]RateLimitBudget, len(limits))
+	for i, limit := range limits {
+		inWindow := limiter.callsSince(now.Add(-limit.Window))
+		budget := RateLimitBudget{RateLimit: limit, Remaining: limit.Limit - len(inWindow)}
+		if budget.Remaining < 0 {
+			budget.Remaining = 0
+		}
+		if len(inWindow) > 0 {
+			budget.ResetIn = inWindow[0].Add(limit.Window).Sub(now)
+		}
+		budgets[i] = budget
+	}
+	return budgets
+}
+
+// reserve records a call if every limit allows it. Otherwise it returns the
+// limit that is exhausted longest and how long until it frees up.
+func (limiter *RateLimiter) reserve() (RateLimit, time.Duration) {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+
+	now := limiter.clock()
+	limiter.prune(now)
+
+	var blocking RateLimit
+	var delay time.Duration
+	for _, limit := range limiter.rateLimits() {
+		inWindow := limiter.callsSince(now.Add(-limit.Window))
+		if len(inWindow) < limit.Limit {
+			continue
+		}
+		// The call is allowed once enough of the calls in the window expire
+		// to bring it under the limit.
+		wait := inWindow[len(inWindow)-limit.Limit].Add(limit.Window).Sub(now)
+		if wait > delay {
+			blocking, delay = limit, wait
+		}
+	}
+	if delay > 0 {
+		return blocking, delay
+	}
+
+	limiter.calls = append(limiter.calls, now)
+	return RateLimit{}, 0
+}
+
+// callsSince returns the recorded calls made after start.
+func (limiter *RateLimiter) callsSince(start time.Time) []time.Time {
+	i := sort.Search(len(limiter.calls), func(i int) bool {
+		return limiter.calls[i].After(start)
+	})
+	return limiter.calls[i:]
+}
+
+// prune forgets calls older than the longest window.
+func (limiter *RateLimiter) prune(now time.Time) {
+	var longest time.Duration
+	for _, limit := range limiter.rateLimits() {
+		if limit.Window > longest {
+			longest = limit.Window
+		}
+	}
+	expired := len(limiter.calls) - len(limiter.callsSince(now.Add(-longest)))
+	if expired > 0 {
+		limiter.calls = append(limiter.calls[:0], limiter.calls[expired:]...)
+	}
+}
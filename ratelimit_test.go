@@ -0,0 +1,183 @@
+package namecheap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a manually advanced time source for RateLimiter tests.
+type fakeClock struct {
+	mu sync.Mutex
+	t  time.Time
+}
+
+func (c *fakeClock) now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.t
+}
+
+func (c *fakeClock) advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.t = c.t.Add(d)
+}
+
+func TestRateLimiterWindows(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	limiter := NewRateLimiter(false,
+		RateLimit{Limit: 2, Window: time.Minute},
+		RateLimit{Limit: 3, Window: time.Hour},
+	)
+	limiter.now = clock.now
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if err := limiter.Wait(ctx); err != nil {
+			t.Fatalf("Wait #%d returned error: %v", i+1, err)
+		}
+	}
+
+	err := limiter.Wait(ctx)
+	var rateErr *RateLimitError
+	if !errors.As(err, &rateErr) {
+		t.Fatalf("Wait over the minute limit returned %v, want *RateLimitError", err)
+	}
+	if rateErr.Window != time.Minute || rateErr.RetryAfter != time.Minute {
+		t.Errorf("RateLimitError = %+v, want minute window retrying after 1m", rateErr)
+	}
+
+	clock.advance(time.Minute)
+	if err := limiter.Wait(ctx); err != nil {
+		t.Fatalf("Wait after the minute window returned error: %v", err)
+	}
+
+	clock.advance(time.Minute)
+	err = limiter.Wait(ctx)
+	if !errors.As(err, &rateErr) || rateErr.Window != time.Hour {
+		t.Fatalf("Wait over the hour limit returned %v, want hour *RateLimitError", err)
+	}
+	if rateErr.RetryAfter != 58*time.Minute {
+		t.Errorf("RateLimitError.RetryAfter = %v, want 58m", rateErr.RetryAfter)
+	}
+
+	want := []RateLimitBudget{
+		{RateLimit: RateLimit{Limit: 2, Window: time.Minute}, Remaining: 2, ResetIn: 0},
+		{RateLimit: RateLimit{Limit: 3, Window: time.Hour}, Remaining: 0, ResetIn: 58 * time.Minute},
+	}
+	got := limiter.Remaining()
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Remaining()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestRateLimiterConcurrent(t *testing.T) {
+	limiter := NewRateLimiter(false, RateLimit{Limit: 10, Window: time.Hour})
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	allowed := 0
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if limiter.Wait(context.Background()) == nil {
+				mu.Lock()
+				allowed++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if allowed != 10 {
+		t.Errorf("%d concurrent calls were allowed, want 10", allowed)
+	}
+}
+
+func TestRateLimiterBlocking(t *testing.T) {
+	limiter := NewRateLimiter(true, RateLimit{Limit: 1, Window: 50 * time.Millisecond})
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 2; i++ {
+		if err := limiter.Wait(ctx); err != nil {
+			t.Fatalf("Wait #%d returned error: %v", i+1, err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("second Wait returned after %v, want at least 50ms", elapsed)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := limiter.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Wait with expiring context returned %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestClientRateLimiter(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprint(w, retryRespXML)
+	})
+	client.RateLimiter = NewRateLimiter(false, RateLimit{Limit: 1, Window: time.Hour})
+
+	if _, err := client.DomainGetInfo("example.com"); err != nil {
+		t.Errorf("DomainGetInfo returned error: %v", err)
+	}
+	var rateErr *RateLimitError
+	if _, err := client.DomainGetInfo("example.com"); !errors.As(err, &rateErr) {
+		t.Errorf("DomainGetInfo over the limit returned %v, want *RateLimitError", err)
+	}
+	if calls != 1 {
+		t.Errorf("server saw %d calls, want 1", calls)
+	}
+}
+
+func TestRateLimiterInvalidLimits(t *testing.T) {
+	limiter := NewRateLimiter(false,
+		RateLimit{Limit: 0, Window: time.Minute},
+		RateLimit{Limit: -1, Window: time.Minute},
+		RateLimit{Limit: 1, Window: 0},
+		RateLimit{Limit: 1, Window: time.Hour},
+	)
+	ctx := context.Background()
+
+	if err := limiter.Wait(ctx); err != nil {
+		t.Fatalf("first Wait returned error: %v", err)
+	}
+	var limitErr *RateLimitError
+	if err := limiter.Wait(ctx); !errors.As(err, &limitErr) || limitErr.RateLimit != (RateLimit{Limit: 1, Window: time.Hour}) {
+		t.Errorf("second Wait returned error %v, want the 1 per hour limit", err)
+	}
+	if budgets := limiter.Remaining(); len(budgets) != 1 {
+		t.Errorf("Remaining returned %d budgets, want only the valid limit", len(budgets))
+	}
+}
+
+func TestRateLimiterZeroValue(t *testing.T) {
+	limiter := &RateLimiter{Block: true}
+
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait returned error: %v", err)
+	}
+	budgets := limiter.Remaining()
+	if len(budgets) != len(DefaultRateLimits) {
+		t.Fatalf("Remaining returned %d budgets, want %d", len(budgets), len(DefaultRateLimits))
+	}
+	if want := DefaultRateLimits[0].Limit - 1; budgets[0].Remaining != want {
+		t.Errorf("Remaining per minute is %d, want %d", budgets[0].Remaining, want)
+	}
+}
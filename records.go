@@ -0,0 +1,211 @@
+package namecheap
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// RecordType is the type of a DomainDNSHost.
+type RecordType string
+
+const (
+	RecordA      RecordType = "A"
+	RecordAAAA   RecordType = "AAAA"
+	RecordCNAME  RecordType = "CNAME"
+	RecordMX     RecordType = "MX"
+	RecordMXE    RecordType = "MXE" // MX easy: the IPv4 address of a mail server
+	RecordTXT    RecordType = "TXT"
+	RecordNS     RecordType = "NS"
+	RecordCAA    RecordType = "CAA"
+	RecordALIAS  RecordType = "ALIAS"
+	RecordURL    RecordType = "URL"    // unmasked redirect (302)
+	RecordURL301 RecordType = "URL301" // permanent redirect
+	RecordFRAME  RecordType = "FRAME"  // masked redirect
+)
+
+// Namecheap accepts TTLs between MinTTL and MaxTTL seconds. A zero TTL is
+// left out of the request and Namecheap uses its default of 1800.
+const (
+	MinTTL = 60
+	MaxTTL = 60000
+)
+
+// ErrInvalidHost matches any *HostError with errors.Is.
+var ErrInvalidHost = errors.New("namecheap: invalid host record")
+
+// HostError reports why a host record failed validation.
+type HostError struct {
+	// Index is the position of the record in the list passed to
+	// ValidateHosts, or -1 when a single record was validated.
+	Index int
+	Host  DomainDNSHost
+	Err   error
+}
+
+func (err *HostError) Error() string {
+	if err.Index < 0 {
+		return fmt.Sprintf("host %s %s: %v", err.Host.Name, err.Host.Type, err.Err)
+	}
+	return fmt.Sprintf("host %d (%s %s): %v", err.Index+1, err.Host.Name, err.Host.Type, err.Err)
+}
+
+func (err *HostError) Unwrap() error {
+	return err.Err
+}
+
+// Is reports whether target is ErrInvalidHost.
+func (err *HostError) Is(target error) bool {
+	return target == ErrInvalidHost
+}
+
+// Validate checks a single record: its name, its TTL, and its address and
+// MXPref as required by its type.
+func (h DomainDNSHost) Validate() error {
+	if err := h.validate(); err != nil {
+		return &HostError{Index: -1, Host: h, Err: err}
+	}
+	return nil
+}
+
+func (h DomainDNSHost) validate() error {
+	if !validRecordName(h.Name) {
+		return fmt.Errorf("invalid name %q", h.Name)
+	}
+	if h.TTL != 0 && (h.TTL < MinTTL || h.TTL > MaxTTL) {
+		return fmt.Errorf("TTL %d is outside %d-%d", h.TTL, MinTTL, MaxTTL)
+	}
+	if h.Address == "" {
+		return errors.New("address is empty")
+	}
+
+	switch RecordType(strings.ToUpper(string(h.Type))) {
+	case RecordA, RecordMXE:
+		if ip := net.ParseIP(h.Address); ip == nil || ip.To4() == nil {
+			return fmt.Errorf("%q is not an IPv4 address", h.Address)
+		}
+	case RecordAAAA:
+		if ip := net.ParseIP(h.Address); ip == nil || ip.To4() != nil {
+			return fmt.Errorf("%q is not an IPv6 address", h.Address)
+		}
+	case RecordCNAME, RecordNS, RecordALIAS:
+		if !validRecordTarget(h.Address) {
+			return fmt.Errorf("%q is not a fully qualified domain name", h.Address)
+		}
+	case RecordMX:
+		if !validRecordTarget(h.Address) {
+			return fmt.Errorf("%q is not a fully qualified domain name", h.Address)
+		}
+		if h.MXPref < 0 || h.MXPref > 65535 {
+			return fmt.Errorf("MXPref %d is outside 0-65535", h.MXPref)
+		}
+	case RecordTXT:
+	case RecordCAA:
+		return validateCAA(h.Address)
+	case RecordURL, RecordURL301, RecordFRAME:
+		target := h.Address
+		if !strings.Contains(target, "://") {
+			target = "http://" + target
+		}
+		u, err := url.Parse(target)
+		if err != nil || u.Host == "" || u.Scheme != "http" && u.Scheme != "https" {
+			return fmt.Errorf("%q is not an http or https URL", h.Address)
+		}
+	default:
+		return fmt.Errorf("unknown record type %q", h.Type)
+	}
+	return nil
+}
+
+// ValidateHosts validates every record and checks the rules that span
+// records: a CNAME cannot be at the apex or share its name with any other
+// record. All problems found are returned together.
+func ValidateHosts(hosts []DomainDNSHost) error {
+	var errs []error
+	names := make(map[string]int, len(hosts))
+	for _, h := range hosts {
+		names[strings.ToLower(h.Name)]++
+	}
+
+	for i, h := range hosts {
+		err := h.validate()
+		if err == nil && strings.EqualFold(string(h.Type), string(RecordCNAME)) {
+			if h.Name == "@" {
+				err = errors.New("CNAME is not allowed at the apex; use ALIAS instead")
+			} else if names[strings.ToLower(h.Name)] > 1 {
+				err = fmt.Errorf("CNAME cannot coexist with other records named %q", h.Name)
+			}
+		}
+		if err != nil {
+			errs = append(errs, &HostError{Index: i, Host: h, Err: err})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validRecordName reports whether name can name a host record: "@" for the
+// apex, or dot-separated labels of letters, digits, hyphens and underscores,
+// with an optional leading "*" wildcard label.
+func validRecordName(name string) bool {
+	if name == "@" {
+		return true
+	}
+	labels := strings.Split(name, ".")
+	for i, label := range labels {
+		if label == "*" && i == 0 {
+			continue
+		}
+		if !validRecordLabel(label) {
+			return false
+		}
+	}
+	return true
+}
+
+// validRecordTarget reports whether name can be the target of a CNAME, ALIAS,
+// MX or NS record: a fully qualified name of two or more labels, with an
+// optional trailing dot. Unlike a hostname its labels may hold underscores,
+// as DKIM keys (selector1._domainkey.example.com) and certificate validation
+// records (_x1.acm-validations.aws) do.
+func validRecordTarget(name string) bool {
+	labels := strings.Split(strings.TrimSuffix(name, "."), ".")
+	if len(labels) < 2 {
+		return false
+	}
+	for _, label := range labels {
+		if !validRecordLabel(label) {
+			return false
+		}
+	}
+	return true
+}
+
+// validRecordLabel reports whether label is a DNS label of letters, digits,
+// hyphens and underscores, at most 63 characters long.
+func validRecordLabel(label string) bool {
+	return label != "" && len(label) <= 63 && ValidDomainName(strings.ReplaceAll(label, "_", ""))
+}
+
+// validateCAA checks the "flags tag value" form of a CAA record.
+func validateCAA(address string) error {
+	fields := strings.SplitN(address, " ", 3)
+	if len(fields) != 3 || fields[2] == "" {
+		return fmt.Errorf("CAA %q must be of the form: flags tag value", address)
+	}
+	if flags, err := strconv.Atoi(fields[0]); err != nil || flags < 0 || flags > 255 {
+		return fmt.Errorf("CAA flags %q must be a number from 0 to 255", fields[0])
+	}
+	tag := fields[1]
+	if tag == "" {
+		return errors.New("CAA tag is empty")
+	}
+	for _, c := range tag {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9') {
+			return fmt.Errorf("CAA tag %q must be alphanumeric", tag)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package namecheap
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestDomainDNSHostValidate(t *testing.T) {
+	valid := []DomainDNSHost{
+		{Name: "@", Type: RecordA, Address: "1.2.3.4", TTL: 60},
+		{Name: "*", Type: RecordAAAA, Address: "2001:db8::1", TTL: 60000},
+		{Name: "www", Type: RecordCNAME, Address: "domain.com."},
+		{Name: "selector1._domainkey", Type: RecordCNAME, Address: "selector1-contoso-com._domainkey.contoso.onmicrosoft.com."},
+		{Name: "_x1.www", Type: RecordCNAME, Address: "_def.acm-validations.aws."},
+		{Name: "@", Type: RecordMX, Address: "mx.example.com", MXPref: 10},
+		{Name: "@", Type: RecordMXE, Address: "5.6.7.8"},
+		{Name: "_dmarc", Type: RecordTXT, Address: "v=DMARC1; p=none"},
+		{Name: "dev", Type: RecordNS, Address: "ns1.other.net"},
+		{Name: "@", Type: RecordCAA, Address: `0 issue "letsencrypt.org"`},
+		{Name: "www", Type: "a", Address: "1.2.3.4"},
+		{Name: "@", Type: "mx", Address: "mx.example.com", MXPref: 10},
+		{Name: "@", Type: RecordALIAS, Address: "lb.example.net"},
+		{Name: "go", Type: RecordURL, Address: "example.com/path"},
+		{Name: "go", Type: RecordURL301, Address: "https://example.com/"},
+		{Name: "*.app", Type: RecordFRAME, Address: "http://example.com"},
+	}
+	for _, h := range valid {
+		if err := h.Validate(); err != nil {
+			t.Errorf("Validate(%+v) returned error: %v", h, err)
+		}
+	}
+
+	invalid := []DomainDNSHost{
+		{Name: "", Type: RecordA, Address: "1.2.3.4"},
+		{Name: "bad name", Type: RecordA, Address: "1.2.3.4"},
+		{Name: "www", Type: RecordA, Address: "1.2.3.4", TTL: 59},
+		{Name: "www", Type: RecordA, Address: "1.2.3.4", TTL: 60001},
+		{Name: "www", Type: RecordA, Address: "2001:db8::1"},
+		{Name: "www", Type: RecordA, Address: ""},
+		{Name: "www", Type: RecordAAAA, Address: "1.2.3.4"},
+		{Name: "www", Type: RecordCNAME, Address: "localhost"},
+		{Name: "www", Type: RecordCNAME, Address: "bad host.example.com"},
+		{Name: "www", Type: RecordCNAME, Address: "example..com"},
+		{Name: "@", Type: RecordMX, Address: "mx.example.com", MXPref: 70000},
+		{Name: "@", Type: RecordMXE, Address: "mx.example.com"},
+		{Name: "@", Type: RecordCAA, Address: "256 issue letsencrypt.org"},
+		{Name: "@", Type: RecordCAA, Address: "0 is-sue letsencrypt.org"},
+		{Name: "@", Type: RecordCAA, Address: "0 issue"},
+		{Name: "_sip._tcp", Type: "SRV", Address: "10 5 5060 sip.example.com."},
+		{Name: "www", Type: "aaaa", Address: "1.2.3.4"},
+		{Name: "go", Type: RecordURL, Address: "ftp://example.com"},
+		{Name: "www", Type: "PTR", Address: "host.example.com"},
+	}
+	for _, h := range invalid {
+		err := h.Validate()
+		if !errors.Is(err, ErrInvalidHost) {
+			t.Errorf("Validate(%+v) returned %v, want ErrInvalidHost", h, err)
+		}
+	}
+}
+
+func TestValidateHosts(t *testing.T) {
+	cases := []struct {
+		hosts   []DomainDNSHost
+		invalid []int
+	}{
+		{
+			hosts: []DomainDNSHost{
+				{Name: "@", Type: RecordA, Address: "1.2.3.4"},
+				{Name: "www", Type: RecordCNAME, Address: "domain.com"},
+			},
+		},
+		{
+			// Records other services ask for, whose targets hold underscores.
+			hosts: []DomainDNSHost{
+				{Name: "@", Type: RecordA, Address: "1.2.3.4"},
+				{Name: "selector1._domainkey", Type: RecordCNAME, Address: "selector1-contoso-com._domainkey.contoso.onmicrosoft.com."},
+				{Name: "_3f1a.www", Type: RecordCNAME, Address: "_def.acm-validations.aws."},
+			},
+		},
+		{
+			hosts: []DomainDNSHost{
+				{Name: "@", Type: RecordCNAME, Address: "other.com"},
+			},
+			invalid: []int{0},
+		},
+		{
+			hosts: []DomainDNSHost{
+				{Name: "www", Type: "cname", Address: "other.com"},
+				{Name: "www", Type: "txt", Address: "hello"},
+			},
+			invalid: []int{0},
+		},
+		{
+			hosts: []DomainDNSHost{
+				{Name: "www", Type: RecordCNAME, Address: "other.com"},
+				{Name: "WWW", Type: RecordTXT, Address: "hello"},
+				{Name: "api", Type: RecordA, Address: "999.1.1.1"},
+			},
+			invalid: []int{0, 2},
+		},
+	}
+
+	for _, c := range cases {
+		err := ValidateHosts(c.hosts)
+		var got []int
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			for _, e := range joined.Unwrap() {
+				var hostErr *HostError
+				if errors.As(e, &hostErr) {
+					got = append(got, hostErr.Index)
+				}
+			}
+		}
+		if len(got) != len(c.invalid) {
+			t.Errorf("ValidateHosts(%+v) flagged %v, want %v", c.hosts, got, c.invalid)
+			continue
+		}
+		for i := range got {
+			if got[i] != c.invalid[i] {
+				t.Errorf("ValidateHosts(%+v) flagged %v, want %v", c.hosts, got, c.invalid)
+				break
+			}
+		}
+	}
+}
+
+func TestDomainDNSSetHostsValidatesFirst(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("DomainDNSSetHosts sent a request for invalid hosts")
+	})
+
+	hosts := []DomainDNSHost{{Name: "@", Type: RecordCNAME, Address: "other.com"}}
+	if _, err := client.DomainDNSSetHosts("domain", "com", hosts); !errors.Is(err, ErrInvalidHost) {
+		t.Errorf("DomainDNSSetHosts returned %v, want ErrInvalidHost", err)
+	}
+}
@@ -117,3 +117,23 @@ func (reg *Registrant) addValues(u url.Values) error {
 
 	return nil
 }
+
+// ToRegistrant returns the contacts as a Registrant, ready to be modified and
+// passed to DomainSetContacts.
+func (contacts *DomainContactsResult) ToRegistrant() *Registrant {
+	reg := new(Registrant)
+	val := reflect.ValueOf(reg).Elem()
+	for prefix, contact := range map[string]DomainContact{
+		"Registrant": contacts.Registrant,
+		"Tech":       contacts.Tech,
+		"Admin":      contacts.Admin,
+		"AuxBilling": contacts.AuxBilling,
+	} {
+		contactVal := reflect.ValueOf(contact)
+		for i := 0; i < contactVal.NumField(); i++ {
+			fieldName := prefix + contactVal.Type().Field(i).Name
+			val.FieldByName(fieldName).SetString(contactVal.Field(i).String())
+		}
+	}
+	return reg
+}
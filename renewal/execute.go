@@ -0,0 +1,176 @@
+package renewal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	namecheap "github.com/scrambleshell/namecheap-go"
+)
+
+// defaultMargin is the default ExecuteOptions.Margin, in percent.
+const defaultMargin = 10
+
+// ErrNoCap is returned by Execute when no spending cap, or no currency for
+// it, is set.
+var ErrNoCap = errors.New("renewal: a spending cap and its currency are required")
+
+// ExecuteOptions configure Execute.
+type ExecuteOptions struct {
+	// Cap is the most Execute may spend, compared against the estimated
+	// cost plus Margin before each renewal and the charged amount after it.
+	// Required.
+	Cap namecheap.Money
+	// Currency is the currency of Cap. Items priced in another currency are
+	// not renewed. Required.
+	Currency namecheap.Currency
+	// Margin is added to each estimated cost, in percent of it, before it is
+	// checked against what is left of the cap, since the amount charged can
+	// be higher than the listed price: the ICANN fee is charged on top of
+	// it and prices change. Defaults to 10.
+	Margin int
+	// IncludeAutoRenew renews items that have auto-renew on as well. By
+	// default those are left for Namecheap to renew.
+	IncludeAutoRenew bool
+}
+
+// Renewal is the outcome of renewing, or skipping, one item.
+type Renewal struct {
+	Item Item
+	// Charged is the amount Namecheap charged for the renewal.
+	Charged namecheap.Money
+	// Skipped says why the item was not renewed; it is empty for items
+	// Execute tried to renew.
+	Skipped string
+	Err     error
+}
+
+// Renewed reports whether the item was renewed.
+func (r Renewal) Renewed() bool {
+	return r.Skipped == "" && r.Err == nil
+}
+
+// Spent returns the total charged by the renewals in the report.
+func (r *Report) Spent() namecheap.Money {
+	var spent namecheap.Money
+	for _, renewal := range r.Renewals {
+		spent += renewal.Charged
+	}
+	return spent
+}
+
+// Execute renews the items of the report in expiry order, for the number of
+// years the report was planned with, without spending more than opts.Cap.
+// An item whose estimated cost plus opts.Margin does not fit in what is left
+// of the cap is skipped, as are unpriced items, items priced in another
+// currency than the cap and, unless opts.IncludeAutoRenew is set, items with
+// auto-renew on. What is left of the cap is worked out from the amounts
+// actually charged; once they reach the cap the remaining items are skipped.
+// A single renewal charged more than its estimate plus the margin can still
+// take the total over the cap.
+//
+// A failed renewal is recorded and Execute moves on to the next item. When
+// the failure leaves it unknown whether Namecheap renewed the item, such as a
+// network error or a response without a result, its estimated cost plus the
+// margin is counted against the cap as if it had been charged. Execute stops with an error when ctx is done or the client
+// refuses to spend (namecheap.ErrProductionSpending). The outcomes are
+// returned and also stored in r.Renewals.
+func (r *Report) Execute(ctx context.Context, client *namecheap.Client, opts ExecuteOptions) ([]Renewal, error) {
+	if opts.Cap <= 0 || opts.Currency == "" {
+		return nil, ErrNoCap
+	}
+
+	margin := opts.Margin
+	if margin <= 0 {
+		margin = defaultMargin
+	}
+
+	r.Renewals, r.CapCurrency = nil, opts.Currency
+	// spent counts the charged amounts and the estimated cost, with the
+	// margin, of renewals with an unknown outcome.
+	var spent namecheap.Money
+	for _, item := range r.Items {
+		renewal := Renewal{Item: item}
+		budget := withMargin(item.Cost, margin)
+		switch {
+		case item.AutoRenew && !opts.IncludeAutoRenew:
+			renewal.Skipped = "auto-renew is on"
+		case !item.Priced:
+			renewal.Skipped = "price unknown"
+		case item.Currency != opts.Currency:
+			renewal.Skipped = fmt.Sprintf("priced in %s, not %s", item.Currency, opts.Currency)
+		case spent >= opts.Cap:
+			renewal.Skipped = fmt.Sprintf("the cap of %s %s is used up (%s spent)", opts.Cap, opts.Currency, spent)
+		case spent+budget > opts.Cap:
+			renewal.Skipped = fmt.Sprintf("would exceed the cap of %s %s (%s spent)", opts.Cap, opts.Currency, spent)
+		}
+		if renewal.Skipped != "" {
+			r.Renewals = append(r.Renewals, renewal)
+			continue
+		}
+
+		if err := ctx.Err(); err != nil {
+			return r.Renewals, err
+		}
+		var known bool
+		renewal.Charged, known, renewal.Err = renew(ctx, client, item, r.Years)
+		spent += renewal.Charged
+		if !known {
+			spent += budget
+		}
+		r.Renewals = append(r.Renewals, renewal)
+		if errors.Is(renewal.Err, namecheap.ErrProductionSpending) {
+			return r.Renewals, renewal.Err
+		}
+		if err := ctx.Err(); err != nil {
+			return r.Renewals, err
+		}
+	}
+	return r.Renewals, nil
+}
+
+// withMargin returns cost raised by percent, rounded up to the cent.
+func withMargin(cost namecheap.Money, percent int) namecheap.Money {
+	return cost + (cost*namecheap.Money(percent)+99)/100
+}
+
+// renew renews the item and returns the amount charged. known is false when
+// the request failed in a way that does not say whether Namecheap renewed
+// the item, for example a network error after the request was sent.
+func renew(ctx context.Context, client *namecheap.Client, item Item, years int) (charged namecheap.Money, known bool, err error) {
+	switch item.Kind {
+	case KindDomain:
+		result, err := client.DomainRenewContext(ctx, item.Name, years)
+		if err != nil {
+			return 0, outcomeKnown(err), err
+		}
+		if result == nil {
+			return 0, false, fmt.Errorf("renewing %s returned no result", item.Name)
+		}
+		if !result.Renewed {
+			return toMoney(result.ChargedAmount), true, fmt.Errorf("renewing %s was not successful", item.Name)
+		}
+		return toMoney(result.ChargedAmount), true, nil
+	case KindWhoisguard:
+		result, err := client.WhoisguardRenewContext(ctx, item.WhoisguardID, years)
+		if err != nil {
+			return 0, outcomeKnown(err), err
+		}
+		if result == nil {
+			return 0, false, fmt.Errorf("renewing WhoisGuard %d of %s returned no result", item.WhoisguardID, item.Name)
+		}
+		if !result.Renewed {
+			return toMoney(result.ChargedAmount), true, fmt.Errorf("renewing WhoisGuard %d of %s was not successful", item.WhoisguardID, item.Name)
+		}
+		return toMoney(result.ChargedAmount), true, nil
+	}
+	return 0, true, fmt.Errorf("unknown item kind %q", item.Kind)
+}
+
+// outcomeKnown reports whether a failed renew request certainly did not
+// renew anything: Namecheap answered with an error, or the client refused to
+// send the request.
+func outcomeKnown(err error) bool {
+	var apiErr *namecheap.ApiError
+	return errors.As(err, &apiErr) || errors.Is(err, namecheap.ErrProductionSpending)
+}
@@ -0,0 +1,289 @@
+// Package renewal finds domains and WhoisGuard subscriptions that are about
+// to expire, estimates what renewing them will cost, reports on them as
+// text, JSON or CSV, and can optionally renew them within a spending cap.
+package renewal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	namecheap "github.com/scrambleshell/namecheap-go"
+)
+
+const (
+	defaultWithin = 30
+	defaultYears  = 1
+)
+
+// Kind is the kind of product an Item renews.
+type Kind string
+
+const (
+	KindDomain     Kind = "domain"
+	KindWhoisguard Kind = "whoisguard"
+)
+
+// Item is a domain or WhoisGuard subscription expiring within the window.
+type Item struct {
+	Kind Kind
+	// Name is the domain name; for WhoisGuard it is the protected domain.
+	Name         string
+	WhoisguardID int64
+	Expires      namecheap.Date
+	// DaysLeft is negative once the item has expired.
+	DaysLeft int
+	// AutoRenew is the domain's auto-renew setting. WhoisGuard subscriptions
+	// renew together with their domain, so they take it from the domain, and
+	// are reported as not auto-renewing when the domain is not listed.
+	AutoRenew bool
+	// Cost is the estimated price of renewing for the planned number of
+	// years. It is only meaningful when Priced is set.
+	Cost     namecheap.Money
+	Currency namecheap.Currency
+	Priced   bool
+}
+
+// NeedsAttention reports whether the item will lapse unless someone renews
+// it, either because auto-renew is off or because it has already expired.
+func (item Item) NeedsAttention() bool {
+	return !item.AutoRenew || item.DaysLeft < 0
+}
+
+// Options configure Plan.
+type Options struct {
+	// Within is the window in days: items expiring in that many days or
+	// fewer, including already expired ones, are reported. Defaults to 30.
+	Within int
+	// Years is the renewal period used for cost estimates and renewals.
+	// Defaults to 1.
+	Years int
+	// Now returns the current time. Defaults to time.Now.
+	Now func() time.Time
+}
+
+// Report is the result of Plan. Items are sorted by expiry date, soonest
+// first.
+type Report struct {
+	Generated time.Time
+	Within    int
+	Years     int
+	Items     []Item
+	// Total is the estimated cost of renewing every priced item.
+	Total    namecheap.Money
+	Currency namecheap.Currency
+	// Warnings lists domains and subscriptions left out of the report
+	// because their expiry date could not be parsed, and items left out of
+	// Total because their price could not be found or is in another
+	// currency. Execute never renews unpriced items, nor items priced in
+	// another currency than its cap.
+	Warnings []string
+	// Renewals and CapCurrency, the currency of the cap and of the amounts
+	// charged, are filled in by Execute.
+	Renewals    []Renewal
+	CapCurrency namecheap.Currency
+}
+
+// Attention returns the items that need attention, in report order.
+func (r *Report) Attention() []Item {
+	var items []Item
+	for _, item := range r.Items {
+		if item.NeedsAttention() {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// Plan lists the domains and WhoisGuard subscriptions of the account that
+// expire within the window and prices their renewal with UsersGetPricing.
+// An expiry date that cannot be parsed, or a price that cannot be looked up,
+// is reported as a warning rather than an error.
+func Plan(ctx context.Context, client *namecheap.Client, opts Options) (*Report, error) {
+	if opts.Within <= 0 {
+		opts.Within = defaultWithin
+	}
+	if opts.Years <= 0 {
+		opts.Years = defaultYears
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	now := opts.Now()
+
+	domains, err := client.DomainsGetCompleteListContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing domains: %w", err)
+	}
+	whoisguards, err := client.WhoisguardGetListContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing WhoisGuard subscriptions: %w", err)
+	}
+
+	report := &Report{Generated: now, Within: opts.Within, Years: opts.Years}
+	autoRenew := make(map[string]bool, len(domains))
+	for _, d := range domains {
+		autoRenew[strings.ToLower(d.Name)] = d.AutoRenew
+		expires := d.Expires
+		if expires.Unparsed() {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("%s %s: unrecognised date %q; left out", KindDomain, d.Name, expires))
+			continue
+		}
+		if expires.IsZero() {
+			continue
+		}
+		if days := expires.DaysUntil(now); days <= opts.Within {
+			report.Items = append(report.Items, Item{
+				Kind:      KindDomain,
+				Name:      d.Name,
+				Expires:   expires,
+				DaysLeft:  days,
+				AutoRenew: d.AutoRenew,
+			})
+		}
+	}
+	for _, wg := range whoisguards {
+		// Subscriptions not allotted to a domain are not worth renewing.
+		if wg.DomainName == "" {
+			continue
+		}
+		expires := wg.Expires
+		if expires.Unparsed() {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("%s %s: unrecognised date %q; left out", KindWhoisguard, wg.DomainName, expires))
+			continue
+		}
+		if expires.IsZero() {
+			continue
+		}
+		if days := expires.DaysUntil(now); days <= opts.Within {
+			report.Items = append(report.Items, Item{
+				Kind:         KindWhoisguard,
+				Name:         wg.DomainName,
+				WhoisguardID: wg.ID,
+				Expires:      expires,
+				DaysLeft:     days,
+				AutoRenew:    autoRenew[strings.ToLower(wg.DomainName)],
+			})
+		}
+	}
+	sort.SliceStable(report.Items, func(i, j int) bool {
+		return report.Items[i].Expires.Before(report.Items[j].Expires.Time)
+	})
+
+	prices := &priceList{client: client, years: opts.Years, cache: map[string]price{}}
+	for i := range report.Items {
+		item := &report.Items[i]
+		p, err := prices.lookup(ctx, item)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			report.Warnings = append(report.Warnings, fmt.Sprintf("%s %s: %v", item.Kind, item.Name, err))
+			continue
+		}
+		item.Cost, item.Currency, item.Priced = p.amount, p.currency, true
+		if report.Currency == "" {
+			report.Currency = p.currency
+		} else if p.currency != report.Currency {
+			report.Warnings = append(report.Warnings, fmt.Sprintf(
+				"%s %s: priced in %s, not %s; left out of the total", item.Kind, item.Name, p.currency, report.Currency))
+			continue
+		}
+		report.Total += p.amount
+	}
+	return report, nil
+}
+
+type price struct {
+	amount   namecheap.Money
+	currency namecheap.Currency
+	err      error
+}
+
+// priceList looks up renewal prices, asking the API once per product.
+type priceList struct {
+	client *namecheap.Client
+	years  int
+	cache  map[string]price
+}
+
+func (l *priceList) lookup(ctx context.Context, item *Item) (price, error) {
+	productType, category, product := "WHOISGUARD", "", ""
+	if item.Kind == KindDomain {
+		productType, category, product = "DOMAIN", "RENEW", tld(item.Name)
+	}
+	key := productType + "/" + product
+	if p, ok := l.cache[key]; ok {
+		return p, p.err
+	}
+
+	p := price{}
+	results, err := l.client.UsersGetPricingContext(ctx, productType, category, product)
+	if err != nil {
+		p.err = fmt.Errorf("looking up %s pricing: %w", strings.ToLower(productType), err)
+	} else {
+		p.amount, p.currency, p.err = renewalPrice(results, category, product, l.years)
+	}
+	if ctx.Err() == nil {
+		l.cache[key] = p
+	}
+	return p, p.err
+}
+
+var errNoPrice = errors.New("no renewal price listed")
+
+// renewalPrice picks the price for renewing for years from a pricing
+// response, preferring an exact duration and otherwise scaling the yearly
+// price. Category and product are matched case-insensitively when set.
+func renewalPrice(results []namecheap.UsersGetPricingResult, category, product string, years int) (namecheap.Money, namecheap.Currency, error) {
+	var yearly *float64
+	var currency namecheap.Currency
+	for _, result := range results {
+		for _, c := range result.ProductCategory {
+			if category != "" && !strings.EqualFold(c.Name, category) {
+				continue
+			}
+			for _, prod := range c.Product {
+				if product != "" && !strings.EqualFold(prod.Name, product) {
+					continue
+				}
+				for _, p := range prod.Price {
+					if !strings.EqualFold(p.DurationType, "YEAR") {
+						continue
+					}
+					amount := p.YourPrice
+					if amount == 0 {
+						amount = p.Price
+					}
+					if p.Duration == years {
+						return toMoney(amount), namecheap.Currency(p.Currency), nil
+					}
+					if p.Duration == 1 && yearly == nil {
+						yearly, currency = &amount, namecheap.Currency(p.Currency)
+					}
+				}
+			}
+		}
+	}
+	if yearly == nil {
+		return 0, "", errNoPrice
+	}
+	return toMoney(*yearly) * namecheap.Money(years), currency, nil
+}
+
+func toMoney(f float64) namecheap.Money {
+	return namecheap.Money(math.Round(f * 100))
+}
+
+// tld returns everything after the first label, e.g. "co.uk" for
+// example.co.uk, which is how Namecheap names its domain products.
+func tld(domain string) string {
+	if i := strings.Index(domain, "."); i >= 0 {
+		return strings.ToLower(domain[i+1:])
+	}
+	return strings.ToLower(domain)
+}
@@ -0,0 +1,522 @@
+package renewal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	namecheap "github.com/scrambleshell/namecheap-go"
+)
+
+var now = time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC)
+
+// fakeAPI serves the commands the planner uses from fixed data and records
+// the pricing lookups and renewals it is asked for.
+type fakeAPI struct {
+	mu        sync.Mutex
+	pricing   []string
+	renewals  []string
+	failRenew map[string]bool
+	// dropRenew lists domains whose renewal is cut off without a response.
+	dropRenew map[string]bool
+	// emptyRenew lists domains whose renewal gets a response without a
+	// result.
+	emptyRenew map[string]bool
+	// charges overrides the amount charged for renewing a domain.
+	charges map[string]string
+	// domains replaces fakeDomains when set.
+	domains string
+}
+
+const (
+	fakeDomains = `
+      <Domain ID="1" Name="soon.com" Expires="10/25/2026" AutoRenew="false" />
+      <Domain ID="2" Name="auto.net" Expires="11/02/2026" AutoRenew="true" />
+      <Domain ID="3" Name="late.com" Expires="03/01/2027" AutoRenew="false" />
+      <Domain ID="4" Name="gone.com" Expires="10/10/2026" AutoRenew="false" />
+      <Domain ID="5" Name="odd.xyz" Expires="10/30/2026" AutoRenew="true" />`
+	fakeWhoisguards = `
+      <Whoisguard ID="11" DomainName="soon.com" Created="10/20/2025" Expires="10/20/2026" Status="enabled" />
+      <Whoisguard ID="12" DomainName="" Created="10/19/2025" Expires="10/19/2026" Status="unused" />
+      <Whoisguard ID="13" DomainName="late.com" Created="12/31/2025" Expires="12/31/2027" Status="enabled" />`
+)
+
+var fakePrices = map[string]string{
+	"DOMAIN/COM": `
+      <ProductCategory Name="renew">
+        <Product Name="com">
+          <Price Duration="1" DurationType="YEAR" Price="12.98" RegularPrice="12.98" YourPrice="10.98" CouponPrice="0" Currency="USD" />
+          <Price Duration="2" DurationType="YEAR" Price="25.96" RegularPrice="25.96" YourPrice="21.50" CouponPrice="0" Currency="USD" />
+        </Product>
+      </ProductCategory>`,
+	"DOMAIN/NET": `
+      <ProductCategory Name="renew">
+        <Product Name="net">
+          <Price Duration="1" DurationType="YEAR" Price="13.98" RegularPrice="13.98" YourPrice="0" CouponPrice="0" Currency="USD" />
+        </Product>
+      </ProductCategory>`,
+	"WHOISGUARD/": `
+      <ProductCategory Name="whoisguard">
+        <Product Name="whoisguard-protect-one">
+          <Price Duration="1" DurationType="YEAR" Price="2.88" RegularPrice="2.88" YourPrice="2.88" CouponPrice="0" Currency="USD" />
+        </Product>
+      </ProductCategory>`,
+}
+
+func (f *fakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	form := readForm(r)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	command := form.Get("Command")
+	fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?>
+<ApiResponse xmlns="http://api.namecheap.com/xml.response" Status="OK">
+  <CommandResponse Type="%s">`, command)
+	switch command {
+	case "namecheap.domains.getList":
+		domains := fakeDomains
+		if f.domains != "" {
+			domains = f.domains
+		}
+		fmt.Fprintf(w, `<DomainGetListResult>%s</DomainGetListResult>
+    <Paging><TotalItems>%d</TotalItems><CurrentPage>1</CurrentPage><PageSize>100</PageSize></Paging>`,
+			domains, strings.Count(domains, "<Domain "))
+	case "namecheap.whoisguard.getList":
+		fmt.Fprintf(w, `<WhoisguardGetListResult>%s</WhoisguardGetListResult>`, fakeWhoisguards)
+	case "namecheap.users.getPricing":
+		productType := form.Get("ProductType")
+		key := productType + "/" + strings.ToUpper(form.Get("ProductName"))
+		f.pricing = append(f.pricing, key)
+		fmt.Fprintf(w, `<UserGetPricingResult><ProductType Name="%s">%s</ProductType></UserGetPricingResult>`,
+			strings.ToLower(productType), fakePrices[key])
+	case "namecheap.domains.renew":
+		name := form.Get("DomainName")
+		f.renewals = append(f.renewals, name)
+		if f.dropRenew[name] {
+			panic(http.ErrAbortHandler)
+		}
+		if f.emptyRenew[name] {
+			break
+		}
+		charged := charge(form.Get("Years"), "10.98")
+		if c, ok := f.charges[name]; ok {
+			charged = c
+		}
+		fmt.Fprintf(w, `<DomainRenewResult DomainName="%s" DomainID="1" Renew="%t" OrderID="1" TransactionID="1" ChargedAmount="%s" />`,
+			name, !f.failRenew[name], charged)
+	case "namecheap.whoisguard.renew":
+		id := form.Get("WhoisguardID")
+		f.renewals = append(f.renewals, "whoisguard "+id)
+		fmt.Fprintf(w, `<WhoisguardRenewResult WhoisguardId="%s" Years="1" Renew="true" OrderId="1" TransactionId="1" ChargedAmount="%s" />`,
+			id, charge(form.Get("Years"), "2.88"))
+	}
+	fmt.Fprint(w, `</CommandResponse></ApiResponse>`)
+}
+
+// readForm returns the request parameters, which the client sends in the
+// body for GET requests such as users.getPricing too.
+func readForm(r *http.Request) url.Values {
+	body, _ := io.ReadAll(r.Body)
+	form, _ := url.ParseQuery(string(body))
+	return form
+}
+
+func charge(years, yearly string) string {
+	n, _ := strconv.Atoi(years)
+	m, _ := namecheap.ParseMoney(yearly)
+	return (m * namecheap.Money(n)).String()
+}
+
+func setupFake(t *testing.T) (*fakeAPI, *namecheap.Client) {
+	fake := &fakeAPI{failRenew: map[string]bool{}, dropRenew: map[string]bool{}, emptyRenew: map[string]bool{}, charges: map[string]string{}}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	client := namecheap.NewClient("anApiUser", "anToken", "anUser")
+	client.BaseURL = server.URL
+	client.AllowProductionSpending = true
+	return fake, client
+}
+
+func mustDate(t *testing.T, s string) namecheap.Date {
+	t.Helper()
+	d, err := namecheap.ParseDate(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func planFake(t *testing.T, years int) (*fakeAPI, *namecheap.Client, *Report) {
+	t.Helper()
+	fake, client := setupFake(t)
+	report, err := Plan(context.Background(), client, Options{Years: years, Now: func() time.Time { return now }})
+	if err != nil {
+		t.Fatalf("Plan returned error: %v", err)
+	}
+	return fake, client, report
+}
+
+func TestPlan(t *testing.T) {
+	fake, _, report := planFake(t, 0)
+
+	want := []Item{
+		{Kind: KindDomain, Name: "gone.com", Expires: mustDate(t, "10/10/2026"), DaysLeft: -8, Cost: 1098, Currency: "USD", Priced: true},
+		{Kind: KindWhoisguard, Name: "soon.com", WhoisguardID: 11, Expires: mustDate(t, "10/20/2026"), DaysLeft: 2, Cost: 288, Currency: "USD", Priced: true},
+		{Kind: KindDomain, Name: "soon.com", Expires: mustDate(t, "10/25/2026"), DaysLeft: 7, Cost: 1098, Currency: "USD", Priced: true},
+		{Kind: KindDomain, Name: "odd.xyz", Expires: mustDate(t, "10/30/2026"), DaysLeft: 12, AutoRenew: true},
+		{Kind: KindDomain, Name: "auto.net", Expires: mustDate(t, "11/02/2026"), DaysLeft: 15, AutoRenew: true, Cost: 1398, Currency: "USD", Priced: true},
+	}
+	if len(report.Items) != len(want) {
+		t.Fatalf("Plan returned %d items, want %d: %+v", len(report.Items), len(want), report.Items)
+	}
+	for i := range want {
+		if report.Items[i] != want[i] {
+			t.Errorf("item %d is %+v, want %+v", i, report.Items[i], want[i])
+		}
+	}
+
+	if report.Within != 30 || report.Years != 1 || !report.Generated.Equal(now) {
+		t.Errorf("Plan used within=%d years=%d at %v, want the defaults at %v", report.Within, report.Years, report.Generated, now)
+	}
+	if report.Total != 3882 || report.Currency != "USD" {
+		t.Errorf("Total is %s %s, want 38.82 USD", report.Total, report.Currency)
+	}
+	if len(report.Warnings) != 1 || !strings.Contains(report.Warnings[0], "odd.xyz") {
+		t.Errorf("Warnings are %q, want one about odd.xyz", report.Warnings)
+	}
+	if got := len(report.Attention()); got != 3 {
+		t.Errorf("Attention returned %d items, want 3", got)
+	}
+
+	// Prices are cached, so soon.com and gone.com share a lookup.
+	if got, want := strings.Join(fake.pricing, " "), "DOMAIN/COM WHOISGUARD/ DOMAIN/XYZ DOMAIN/NET"; got != want {
+		t.Errorf("pricing was looked up for %q, want %q", got, want)
+	}
+}
+
+func TestPlanUnparsableExpiry(t *testing.T) {
+	fake, client := setupFake(t)
+	fake.domains = `
+      <Domain ID="1" Name="soon.com" Expires="10/25/2026" AutoRenew="false" />
+      <Domain ID="6" Name="odd.com" Expires="sometime soon" AutoRenew="false" />`
+
+	report, err := Plan(context.Background(), client, Options{Now: func() time.Time { return now }})
+	if err != nil {
+		t.Fatalf("Plan returned error: %v", err)
+	}
+	for _, item := range report.Items {
+		if item.Name == "odd.com" {
+			t.Errorf("Plan reported %+v, whose expiry date cannot be parsed", item)
+		}
+	}
+	if len(report.Warnings) != 1 || !strings.Contains(report.Warnings[0], "odd.com") {
+		t.Errorf("Warnings are %q, want one about odd.com", report.Warnings)
+	}
+}
+
+func TestPlanYears(t *testing.T) {
+	_, _, report := planFake(t, 2)
+
+	costs := map[string]namecheap.Money{}
+	for _, item := range report.Items {
+		costs[string(item.Kind)+" "+item.Name] = item.Cost
+	}
+	want := map[string]namecheap.Money{
+		"domain gone.com":     2150, // the listed 2-year price
+		"whoisguard soon.com": 576,  // twice the yearly price
+		"domain soon.com":     2150,
+		"domain odd.xyz":      0,
+		"domain auto.net":     2796,
+	}
+	for key, cost := range want {
+		if costs[key] != cost {
+			t.Errorf("%s costs %s, want %s", key, costs[key], cost)
+		}
+	}
+	if report.Total != 7672 {
+		t.Errorf("Total is %s, want 76.72", report.Total)
+	}
+}
+
+func TestPlanError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	client := namecheap.NewClient("anApiUser", "anToken", "anUser")
+	client.BaseURL = server.URL
+
+	if _, err := Plan(context.Background(), client, Options{}); err == nil || !strings.Contains(err.Error(), "listing domains") {
+		t.Errorf("Plan returned error %v, want one about listing domains", err)
+	}
+}
+
+func TestExecute(t *testing.T) {
+	fake, client, report := planFake(t, 0)
+
+	renewals, err := report.Execute(context.Background(), client, ExecuteOptions{Cap: 2000, Currency: "USD"})
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	if got, want := strings.Join(fake.renewals, ", "), "gone.com, whoisguard 11"; got != want {
+		t.Errorf("renewed %q, want %q", got, want)
+	}
+
+	wantSkipped := []string{"", "", "would exceed the cap of 20.00 USD (13.86 spent)", "auto-renew is on", "auto-renew is on"}
+	if len(renewals) != len(wantSkipped) {
+		t.Fatalf("Execute returned %d renewals, want %d", len(renewals), len(wantSkipped))
+	}
+	for i, skipped := range wantSkipped {
+		if renewals[i].Skipped != skipped || renewals[i].Err != nil {
+			t.Errorf("renewal %d of %s was skipped %q with error %v, want skipped %q",
+				i, renewals[i].Item.Name, renewals[i].Skipped, renewals[i].Err, skipped)
+		}
+	}
+	if report.Spent() != 1386 {
+		t.Errorf("Spent returned %s, want 13.86", report.Spent())
+	}
+	if len(report.Renewals) != len(renewals) {
+		t.Errorf("Execute stored %d renewals, want %d", len(report.Renewals), len(renewals))
+	}
+}
+
+func TestExecuteIncludeAutoRenew(t *testing.T) {
+	fake, client, report := planFake(t, 0)
+	fake.failRenew["gone.com"] = true
+
+	renewals, err := report.Execute(context.Background(), client, ExecuteOptions{Cap: 10000, Currency: "USD", IncludeAutoRenew: true})
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	if got, want := strings.Join(fake.renewals, ", "), "gone.com, whoisguard 11, soon.com, auto.net"; got != want {
+		t.Errorf("renewed %q, want %q", got, want)
+	}
+	if renewals[0].Err == nil || renewals[0].Renewed() {
+		t.Errorf("failed renewal of gone.com was reported as %+v", renewals[0])
+	}
+	if renewals[3].Skipped != "price unknown" {
+		t.Errorf("odd.xyz was skipped %q, want %q", renewals[3].Skipped, "price unknown")
+	}
+	if !renewals[4].Renewed() {
+		t.Errorf("auto.net was not renewed: %+v", renewals[4])
+	}
+}
+
+func TestExecuteOvershoot(t *testing.T) {
+	fake, client, report := planFake(t, 0)
+	// Charged more than the 10.98 estimate, which takes spending past the cap.
+	fake.charges["gone.com"] = "15.50"
+
+	renewals, err := report.Execute(context.Background(), client, ExecuteOptions{Cap: 1500, Currency: "USD", IncludeAutoRenew: true})
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	if got, want := strings.Join(fake.renewals, ", "), "gone.com"; got != want {
+		t.Errorf("renewed %q, want %q", got, want)
+	}
+	if got, want := renewals[1].Skipped, "the cap of 15.00 USD is used up (15.50 spent)"; got != want {
+		t.Errorf("renewal after the cap was reached was skipped %q, want %q", got, want)
+	}
+	if report.Spent() != 1550 {
+		t.Errorf("Spent returned %s, want 15.50", report.Spent())
+	}
+}
+
+func TestExecuteUnknownOutcome(t *testing.T) {
+	fake, client, report := planFake(t, 0)
+	fake.dropRenew["gone.com"] = true
+
+	renewals, err := report.Execute(context.Background(), client, ExecuteOptions{Cap: 1300, Currency: "USD"})
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	if renewals[0].Err == nil || renewals[0].Charged != 0 {
+		t.Errorf("renewal of gone.com without a response was reported as %+v", renewals[0])
+	}
+	// The 10.98 estimate for gone.com, with the 10% margin, counts against
+	// the cap.
+	if got, want := renewals[1].Skipped, "would exceed the cap of 13.00 USD (12.08 spent)"; got != want {
+		t.Errorf("WhoisGuard of soon.com was skipped %q, want %q", got, want)
+	}
+	// The aborted handler may still be unwinding, so read under its lock.
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	if got, want := strings.Join(fake.renewals, ", "), "gone.com"; got != want {
+		t.Errorf("renewed %q, want %q", got, want)
+	}
+}
+
+func TestExecuteEmptyResult(t *testing.T) {
+	fake, client, report := planFake(t, 0)
+	fake.emptyRenew["gone.com"] = true
+
+	renewals, err := report.Execute(context.Background(), client, ExecuteOptions{Cap: 1300, Currency: "USD"})
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	if renewals[0].Err == nil || renewals[0].Renewed() {
+		t.Errorf("renewal of gone.com without a result was reported as %+v", renewals[0])
+	}
+	if got, want := renewals[1].Skipped, "would exceed the cap of 13.00 USD (12.08 spent)"; got != want {
+		t.Errorf("WhoisGuard of soon.com was skipped %q, want %q", got, want)
+	}
+}
+
+func TestExecuteMargin(t *testing.T) {
+	fake, client, report := planFake(t, 0)
+
+	// 10.98 fits in the cap but not with the default 10% margin.
+	renewals, err := report.Execute(context.Background(), client, ExecuteOptions{Cap: 1100, Currency: "USD"})
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	if got, want := renewals[0].Skipped, "would exceed the cap of 11.00 USD (0.00 spent)"; got != want {
+		t.Errorf("gone.com was skipped %q, want %q", got, want)
+	}
+
+	// A 1% margin leaves room for it. The first run renewed the WhoisGuard.
+	if _, err := report.Execute(context.Background(), client, ExecuteOptions{Cap: 1110, Currency: "USD", Margin: 1}); err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	if got, want := strings.Join(fake.renewals, ", "), "whoisguard 11, gone.com"; got != want {
+		t.Errorf("renewed %q, want %q", got, want)
+	}
+}
+
+func TestExecuteMixedCurrency(t *testing.T) {
+	fake, client := setupFake(t)
+	report := &Report{Years: 1, Items: []Item{
+		{Kind: KindDomain, Name: "gone.com", Cost: 900, Currency: "EUR", Priced: true},
+		{Kind: KindDomain, Name: "soon.com", Cost: 1098, Currency: "USD", Priced: true},
+	}}
+
+	renewals, err := report.Execute(context.Background(), client, ExecuteOptions{Cap: 1000, Currency: "EUR"})
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	if got, want := strings.Join(fake.renewals, ", "), "gone.com"; got != want {
+		t.Errorf("renewed %q, want %q", got, want)
+	}
+	if got, want := renewals[1].Skipped, "priced in USD, not EUR"; got != want {
+		t.Errorf("soon.com was skipped %q, want %q", got, want)
+	}
+
+	// Amounts charged are in the cap's currency, not the report's.
+	report.Currency = "USD"
+	var b strings.Builder
+	if err := report.WriteText(&b); err != nil {
+		t.Fatalf("WriteText returned error: %v", err)
+	}
+	if !strings.Contains(b.String(), "Spent: 10.98 EUR\n") {
+		t.Errorf("WriteText did not give the spending in EUR:\n%s", b.String())
+	}
+}
+
+func TestExecuteRefused(t *testing.T) {
+	report := &Report{Years: 1, Items: []Item{
+		{Kind: KindDomain, Name: "soon.com", Cost: 1098, Currency: "USD", Priced: true},
+		{Kind: KindDomain, Name: "gone.com", Cost: 1098, Currency: "USD", Priced: true},
+	}}
+	client := namecheap.NewClient("anApiUser", "anToken", "anUser")
+
+	if _, err := report.Execute(context.Background(), client, ExecuteOptions{}); !errors.Is(err, ErrNoCap) {
+		t.Errorf("Execute without a cap returned error %v, want %v", err, ErrNoCap)
+	}
+	if _, err := report.Execute(context.Background(), client, ExecuteOptions{Cap: 10000}); !errors.Is(err, ErrNoCap) {
+		t.Errorf("Execute without a cap currency returned error %v, want %v", err, ErrNoCap)
+	}
+
+	renewals, err := report.Execute(context.Background(), client, ExecuteOptions{Cap: 10000, Currency: "USD"})
+	if !errors.Is(err, namecheap.ErrProductionSpending) {
+		t.Errorf("Execute against production returned error %v, want %v", err, namecheap.ErrProductionSpending)
+	}
+	if len(renewals) != 1 {
+		t.Errorf("Execute went on after being refused: %+v", renewals)
+	}
+}
+
+func TestExecuteCanceled(t *testing.T) {
+	fake, client, report := planFake(t, 0)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := report.Execute(ctx, client, ExecuteOptions{Cap: 10000, Currency: "USD"}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Execute returned error %v, want %v", err, context.Canceled)
+	}
+	if len(fake.renewals) != 0 {
+		t.Errorf("Execute renewed %q after being canceled", fake.renewals)
+	}
+}
+
+func TestRenewalPrice(t *testing.T) {
+	tests := []struct {
+		name, xml string
+		years     int
+		want      namecheap.Money
+		wantErr   bool
+	}{
+		{name: "exact duration", xml: fakePrices["DOMAIN/COM"], years: 2, want: 2150},
+		{name: "scaled yearly price", xml: fakePrices["DOMAIN/NET"], years: 3, want: 4194},
+		{name: "list price without a discount", xml: fakePrices["DOMAIN/NET"], years: 1, want: 1398},
+		{name: "other category", xml: strings.Replace(fakePrices["DOMAIN/COM"], "renew", "register", 1), years: 1, wantErr: true},
+		{name: "nothing listed", years: 1, wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			results := pricingResults(t, test.xml)
+			got, currency, err := renewalPrice(results, "RENEW", "", test.years)
+			if test.wantErr {
+				if !errors.Is(err, errNoPrice) {
+					t.Errorf("renewalPrice returned %s, %v, want %v", got, err, errNoPrice)
+				}
+				return
+			}
+			if err != nil || got != test.want || currency != "USD" {
+				t.Errorf("renewalPrice returned %s %s, %v, want %s USD", got, currency, err, test.want)
+			}
+		})
+	}
+}
+
+func pricingResults(t *testing.T, categories string) []namecheap.UsersGetPricingResult {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?>
+<ApiResponse xmlns="http://api.namecheap.com/xml.response" Status="OK">
+  <CommandResponse Type="namecheap.users.getPricing">
+    <UserGetPricingResult><ProductType Name="domain">%s</ProductType></UserGetPricingResult>
+  </CommandResponse>
+</ApiResponse>`, categories)
+	}))
+	defer server.Close()
+	client := namecheap.NewClient("anApiUser", "anToken", "anUser")
+	client.BaseURL = server.URL
+
+	results, err := client.UsersGetPricing("DOMAIN", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return results
+}
+
+func TestTLD(t *testing.T) {
+	for domain, want := range map[string]string{
+		"example.com":   "com",
+		"Example.CO.UK": "co.uk",
+		"localhost":     "localhost",
+	} {
+		if got := tld(domain); got != want {
+			t.Errorf("tld(%q) = %q, want %q", domain, got, want)
+		}
+	}
+}
@@ -0,0 +1,222 @@
+package renewal
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	namecheap "github.com/scrambleshell/namecheap-go"
+)
+
+// WriteText writes the report as an aligned table for people to read:
+//
+//	Renewals due within 30 days of 2026-10-18, for 1 year: 2 items, 1 needing attention.
+//
+//	  KIND        NAME         EXPIRES     DAYS  AUTO-RENEW  COST
+//	! domain      example.com  2026-10-25  7     no          10.98 USD
+//	  whoisguard  example.com  2026-11-02  15    yes         2.88 USD
+//
+//	Estimated total: 13.86 USD
+//
+// Items that need attention are marked with "!". Warnings and the outcome of
+// Execute follow the table when there are any.
+func (r *Report) WriteText(w io.Writer) error {
+	var b strings.Builder
+	years := "years"
+	if r.Years == 1 {
+		years = "year"
+	}
+	fmt.Fprintf(&b, "Renewals due within %d days of %s, for %d %s: %d items, %d needing attention.\n",
+		r.Within, r.Generated.Format("2006-01-02"), r.Years, years, len(r.Items), len(r.Attention()))
+
+	if len(r.Items) > 0 {
+		tw := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "\n  KIND\tNAME\tEXPIRES\tDAYS\tAUTO-RENEW\tCOST")
+		for _, item := range r.Items {
+			mark := " "
+			if item.NeedsAttention() {
+				mark = "!"
+			}
+			fmt.Fprintf(tw, "%s %s\t%s\t%s\t%d\t%s\t%s\n", mark, item.Kind, item.Name,
+				item.Expires.Format("2006-01-02"), item.DaysLeft, yesNo(item.AutoRenew), cost(item))
+		}
+		tw.Flush()
+		fmt.Fprintf(&b, "\nEstimated total: %s %s\n", r.Total, r.Currency)
+	}
+	for _, warning := range r.Warnings {
+		fmt.Fprintf(&b, "warning: %s\n", warning)
+	}
+	if len(r.Renewals) > 0 {
+		b.WriteString("\n")
+		for _, renewal := range r.Renewals {
+			fmt.Fprintf(&b, "%s %s: %s\n", renewal.Item.Kind, renewal.Item.Name, outcome(renewal))
+		}
+		fmt.Fprintf(&b, "Spent: %s %s\n", r.Spent(), r.CapCurrency)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+func cost(item Item) string {
+	if !item.Priced {
+		return "unknown"
+	}
+	return item.Cost.String() + " " + string(item.Currency)
+}
+
+func outcome(renewal Renewal) string {
+	switch {
+	case renewal.Skipped != "":
+		return "skipped, " + renewal.Skipped
+	case renewal.Err != nil:
+		return "failed: " + renewal.Err.Error()
+	}
+	return "renewed for " + renewal.Charged.String()
+}
+
+// jsonReport is the JSON form of a Report. Money is written as a decimal
+// string so amounts survive the round trip exactly.
+type jsonReport struct {
+	Generated time.Time     `json:"generated"`
+	Within    int           `json:"withinDays"`
+	Years     int           `json:"years"`
+	Items     []jsonItem    `json:"items"`
+	Total     string        `json:"total"`
+	Currency  string        `json:"currency,omitempty"`
+	Warnings  []string      `json:"warnings,omitempty"`
+	Renewals  []jsonRenewal `json:"renewals,omitempty"`
+}
+
+type jsonItem struct {
+	Kind           Kind           `json:"kind"`
+	Name           string         `json:"name"`
+	WhoisguardID   int64          `json:"whoisguardId,omitempty"`
+	Expires        namecheap.Date `json:"expires"`
+	DaysLeft       int            `json:"daysLeft"`
+	AutoRenew      bool           `json:"autoRenew"`
+	NeedsAttention bool           `json:"needsAttention"`
+	Cost           *string        `json:"cost"`
+	Currency       string         `json:"currency,omitempty"`
+}
+
+type jsonRenewal struct {
+	Kind         Kind   `json:"kind"`
+	Name         string `json:"name"`
+	WhoisguardID int64  `json:"whoisguardId,omitempty"`
+	Renewed      bool   `json:"renewed"`
+	Charged      string `json:"charged"`
+	Skipped      string `json:"skipped,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+func newJSONItem(item Item) jsonItem {
+	j := jsonItem{
+		Kind:           item.Kind,
+		Name:           item.Name,
+		WhoisguardID:   item.WhoisguardID,
+		Expires:        item.Expires,
+		DaysLeft:       item.DaysLeft,
+		AutoRenew:      item.AutoRenew,
+		NeedsAttention: item.NeedsAttention(),
+	}
+	if item.Priced {
+		s := item.Cost.String()
+		j.Cost, j.Currency = &s, string(item.Currency)
+	}
+	return j
+}
+
+// WriteJSON writes the report as an indented JSON object. Amounts are
+// decimal strings and the cost of an unpriced item is null.
+func (r *Report) WriteJSON(w io.Writer) error {
+	out := jsonReport{
+		Generated: r.Generated,
+		Within:    r.Within,
+		Years:     r.Years,
+		Items:     make([]jsonItem, 0, len(r.Items)),
+		Total:     r.Total.String(),
+		Currency:  string(r.Currency),
+		Warnings:  r.Warnings,
+	}
+	for _, item := range r.Items {
+		out.Items = append(out.Items, newJSONItem(item))
+	}
+	for _, renewal := range r.Renewals {
+		j := jsonRenewal{
+			Kind:         renewal.Item.Kind,
+			Name:         renewal.Item.Name,
+			WhoisguardID: renewal.Item.WhoisguardID,
+			Renewed:      renewal.Renewed(),
+			Charged:      renewal.Charged.String(),
+			Skipped:      renewal.Skipped,
+		}
+		if renewal.Err != nil {
+			j.Error = renewal.Err.Error()
+		}
+		out.Renewals = append(out.Renewals, j)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(out)
+}
+
+// csvHeader is the header row written by WriteCSV.
+var csvHeader = []string{"kind", "name", "whoisguard_id", "expires", "days_left", "auto_renew", "needs_attention", "cost", "currency", "outcome"}
+
+// WriteCSV writes one row per item, after a header row, for spreadsheets.
+// Cost is empty for unpriced items and outcome is empty until Execute has
+// run.
+func (r *Report) WriteCSV(w io.Writer) error {
+	outcomes := make(map[string]string, len(r.Renewals))
+	for _, renewal := range r.Renewals {
+		outcomes[itemKey(renewal.Item)] = outcome(renewal)
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, item := range r.Items {
+		id, price, currency := "", "", ""
+		if item.WhoisguardID != 0 {
+			id = strconv.FormatInt(item.WhoisguardID, 10)
+		}
+		if item.Priced {
+			price, currency = item.Cost.String(), string(item.Currency)
+		}
+		record := []string{
+			string(item.Kind),
+			item.Name,
+			id,
+			item.Expires.Format("2006-01-02"),
+			strconv.Itoa(item.DaysLeft),
+			strconv.FormatBool(item.AutoRenew),
+			strconv.FormatBool(item.NeedsAttention()),
+			price,
+			currency,
+			outcomes[itemKey(item)],
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func itemKey(item Item) string {
+	return fmt.Sprintf("%s\x00%s\x00%d", item.Kind, strings.ToLower(item.Name), item.WhoisguardID)
+}
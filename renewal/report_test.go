@@ -0,0 +1,138 @@
+package renewal
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func executedReport(t *testing.T) *Report {
+	t.Helper()
+	_, client, report := planFake(t, 0)
+	if _, err := report.Execute(context.Background(), client, ExecuteOptions{Cap: 2000, Currency: "USD"}); err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	return report
+}
+
+func TestWriteText(t *testing.T) {
+	_, _, report := planFake(t, 0)
+
+	var b strings.Builder
+	if err := report.WriteText(&b); err != nil {
+		t.Fatalf("WriteText returned error: %v", err)
+	}
+	want := `Renewals due within 30 days of 2026-10-18, for 1 year: 5 items, 3 needing attention.
+
+  KIND        NAME      EXPIRES     DAYS  AUTO-RENEW  COST
+! domain      gone.com  2026-10-10  -8    no          10.98 USD
+! whoisguard  soon.com  2026-10-20  2     no          2.88 USD
+! domain      soon.com  2026-10-25  7     no          10.98 USD
+  domain      odd.xyz   2026-10-30  12    yes         unknown
+  domain      auto.net  2026-11-02  15    yes         13.98 USD
+
+Estimated total: 38.82 USD
+warning: domain odd.xyz: no renewal price listed
+`
+	if b.String() != want {
+		t.Errorf("WriteText wrote\n%s\nwant\n%s", b.String(), want)
+	}
+}
+
+func TestWriteTextExecuted(t *testing.T) {
+	report := executedReport(t)
+
+	var b strings.Builder
+	if err := report.WriteText(&b); err != nil {
+		t.Fatalf("WriteText returned error: %v", err)
+	}
+	want := `
+domain gone.com: renewed for 10.98
+whoisguard soon.com: renewed for 2.88
+domain soon.com: skipped, would exceed the cap of 20.00 USD (13.86 spent)
+domain odd.xyz: skipped, auto-renew is on
+domain auto.net: skipped, auto-renew is on
+Spent: 13.86 USD
+`
+	if !strings.HasSuffix(b.String(), want) {
+		t.Errorf("WriteText wrote\n%s\nwant it to end with\n%s", b.String(), want)
+	}
+}
+
+func TestWriteTextEmpty(t *testing.T) {
+	report := &Report{Generated: now, Within: 7, Years: 2}
+
+	var b strings.Builder
+	if err := report.WriteText(&b); err != nil {
+		t.Fatalf("WriteText returned error: %v", err)
+	}
+	if want := "Renewals due within 7 days of 2026-10-18, for 2 years: 0 items, 0 needing attention.\n"; b.String() != want {
+		t.Errorf("WriteText wrote %q, want %q", b.String(), want)
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	report := executedReport(t)
+
+	var b strings.Builder
+	if err := report.WriteJSON(&b); err != nil {
+		t.Fatalf("WriteJSON returned error: %v", err)
+	}
+
+	var got struct {
+		Generated time.Time `json:"generated"`
+		Total     string    `json:"total"`
+		Items     []struct {
+			Name           string  `json:"name"`
+			WhoisguardID   int64   `json:"whoisguardId"`
+			Expires        string  `json:"expires"`
+			NeedsAttention bool    `json:"needsAttention"`
+			Cost           *string `json:"cost"`
+		} `json:"items"`
+		Renewals []struct {
+			Renewed bool   `json:"renewed"`
+			Charged string `json:"charged"`
+			Skipped string `json:"skipped"`
+		} `json:"renewals"`
+	}
+	if err := json.Unmarshal([]byte(b.String()), &got); err != nil {
+		t.Fatalf("WriteJSON wrote invalid JSON: %v\n%s", err, b.String())
+	}
+
+	if !got.Generated.Equal(now) || got.Total != "38.82" {
+		t.Errorf("report was generated %v with total %q, want %v and 38.82", got.Generated, got.Total, now)
+	}
+	if len(got.Items) != 5 {
+		t.Fatalf("WriteJSON wrote %d items, want 5", len(got.Items))
+	}
+	if item := got.Items[1]; item.WhoisguardID != 11 || item.Expires != "2026-10-20" || !item.NeedsAttention || item.Cost == nil || *item.Cost != "2.88" {
+		t.Errorf("WhoisGuard item was written as %+v", item)
+	}
+	if got.Items[3].Cost != nil {
+		t.Errorf("unpriced item has cost %q, want null", *got.Items[3].Cost)
+	}
+	if len(got.Renewals) != 5 || !got.Renewals[0].Renewed || got.Renewals[0].Charged != "10.98" || got.Renewals[2].Skipped == "" {
+		t.Errorf("renewals were written as %+v", got.Renewals)
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	report := executedReport(t)
+
+	var b strings.Builder
+	if err := report.WriteCSV(&b); err != nil {
+		t.Fatalf("WriteCSV returned error: %v", err)
+	}
+	want := `kind,name,whoisguard_id,expires,days_left,auto_renew,needs_attention,cost,currency,outcome
+domain,gone.com,,2026-10-10,-8,false,true,10.98,USD,renewed for 10.98
+whoisguard,soon.com,11,2026-10-20,2,false,true,2.88,USD,renewed for 2.88
+domain,soon.com,,2026-10-25,7,false,true,10.98,USD,"skipped, would exceed the cap of 20.00 USD (13.86 spent)"
+domain,odd.xyz,,2026-10-30,12,true,false,,,"skipped, auto-renew is on"
+domain,auto.net,,2026-11-02,15,true,false,13.98,USD,"skipped, auto-renew is on"
+`
+	if b.String() != want {
+		t.Errorf("WriteCSV wrote\n%s\nwant\n%s", b.String(), want)
+	}
+}
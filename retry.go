@@ -0,0 +1,127 @@
+package namecheap
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net"
+	"net/http"
+	"time"
+)
+
+const (
+	defaultRetryBaseDelay = 500 * time.Millisecond
+	defaultRetryMaxDelay  = 30 * time.Second
+)
+
+// nonIdempotentCommands charge the account, create something, send email or
+// otherwise cannot safely run twice. They are only retried when the API refused the call outright because
+// of rate limiting, in which case nothing was executed.
+var nonIdempotentCommands = map[string]bool{
+	domainsCreate:              true,
+	domainsRenew:               true,
+	whoisguardRenew:            true,
+	domainsTransferCreate:      true,
+	sslCreate:                  true,
+	sslRenew:                   true,
+	usersCreateAddFundsRequest: true,
+	usersCreate:                true,
+	usersAddressCreate:         true,
+	usersResetPassword:         true,
+}
+
+// RetryPolicy describes how failed requests are retried.
+//
+// Delays grow exponentially from BaseDelay up to MaxDelay, and each one is
+// jittered to a random value between half and all of the computed delay.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+
+	// Retryable decides whether an error is worth retrying.
+	// Defaults to IsRetryable.
+	Retryable func(err error) bool
+}
+
+// NewRetryPolicy returns a policy making up to maxAttempts attempts with the
+// default delays.
+func NewRetryPolicy(maxAttempts int) *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: maxAttempts,
+		BaseDelay:   defaultRetryBaseDelay,
+		MaxDelay:    defaultRetryMaxDelay,
+	}
+}
+
+// IsRetryable reports whether err is a transient failure: a network error,
+// an HTTP 5xx or 429 response, or a Namecheap "too many requests" error.
+// Context cancellation and deadlines are never retryable.
+func IsRetryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if isRateLimited(err) {
+		return true
+	}
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode >= 500
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// isRateLimited reports whether the API rejected the call without running it.
+func isRateLimited(err error) bool {
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	return errors.Is(err, ErrTooManyRequests)
+}
+
+func (policy *RetryPolicy) shouldRetry(request *ApiRequest, attempt int, err error) bool {
+	if policy == nil || attempt >= policy.MaxAttempts {
+		return false
+	}
+	if nonIdempotentCommands[request.command] {
+		return isRateLimited(err)
+	}
+	if policy.Retryable != nil {
+		return policy.Retryable(err)
+	}
+	return IsRetryable(err)
+}
+
+// backoff returns the jittered delay to wait after the given attempt.
+func (policy *RetryPolicy) backoff(attempt int) time.Duration {
+	maxDelay := policy.MaxDelay
+	if maxDelay <= 0 {
+		maxDelay = defaultRetryMaxDelay
+	}
+	delay := policy.BaseDelay
+	for i := 1; i < attempt && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(delay-half)+1))
+}
+
+func (policy *RetryPolicy) wait(ctx context.Context, attempt int) error {
+	timer := time.NewTimer(policy.backoff(attempt))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,189 @@
+package namecheap
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// failingHandler answers the first failures requests with fail and every
+// later one with respXML, counting the attempts it sees.
+func failingHandler(failures int, fail http.HandlerFunc, respXML string, attempts *int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		*attempts++
+		if *attempts <= failures {
+			fail(w, r)
+			return
+		}
+		fmt.Fprint(w, respXML)
+	}
+}
+
+func statusHandler(code int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(code)
+	}
+}
+
+const retryRespXML = `<?xml version="1.0" encoding="UTF-8"?>
+<ApiResponse xmlns="http://api.namecheap.com/xml.response" Status="OK">
+  <Errors />
+  <RequestedCommand>namecheap.domains.getInfo</RequestedCommand>
+  <CommandResponse Type="namecheap.domains.getInfo">
+    <DomainGetInfoResult ID="1" DomainName="example.com" />
+  </CommandResponse>
+</ApiResponse>`
+
+const tooManyRequestsXML = `<?xml version="1.0" encoding="UTF-8"?>
+<ApiResponse xmlns="http://api.namecheap.com/xml.response" Status="ERROR">
+  <Errors>
+    <Error Number="500000">Too many requests</Error>
+  </Errors>
+</ApiResponse>`
+
+func testRetryPolicy(maxAttempts int) *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: maxAttempts,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    5 * time.Millisecond,
+	}
+}
+
+func TestRetrySucceedsAfterFailures(t *testing.T) {
+	tests := []struct {
+		name string
+		fail http.HandlerFunc
+	}{
+		{"503", statusHandler(http.StatusServiceUnavailable)},
+		{"429", statusHandler(http.StatusTooManyRequests)},
+		{"too many requests", func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, tooManyRequestsXML)
+		}},
+	}
+
+	for _, tt := range tests {
+		setup()
+
+		attempts := 0
+		mux.HandleFunc("/", failingHandler(2, tt.fail, retryRespXML, &attempts))
+		client.RetryPolicy = testRetryPolicy(3)
+
+		info, err := client.DomainGetInfo("example.com")
+		if err != nil {
+			t.Errorf("%s: DomainGetInfo returned error: %v", tt.name, err)
+		} else if info.Name != "example.com" {
+			t.Errorf("%s: DomainGetInfo returned %+v", tt.name, info)
+		}
+		if attempts != 3 {
+			t.Errorf("%s: server saw %d attempts, want 3", tt.name, attempts)
+		}
+
+		teardown()
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	setup()
+	defer teardown()
+
+	attempts := 0
+	mux.HandleFunc("/", failingHandler(5, statusHandler(http.StatusBadGateway), retryRespXML, &attempts))
+	client.RetryPolicy = testRetryPolicy(3)
+
+	_, err := client.DomainGetInfo("example.com")
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusBadGateway {
+		t.Errorf("DomainGetInfo returned error %v, want HTTP 502", err)
+	}
+	if attempts != 3 {
+		t.Errorf("server saw %d attempts, want 3", attempts)
+	}
+}
+
+func TestRetryNonIdempotent(t *testing.T) {
+	setup()
+	defer teardown()
+
+	renewXML := `<?xml version="1.0" encoding="UTF-8"?>
+<ApiResponse xmlns="http://api.namecheap.com/xml.response" Status="OK">
+  <Errors />
+  <RequestedCommand>namecheap.domains.renew</RequestedCommand>
+  <CommandResponse Type="namecheap.domains.renew">
+    <DomainRenewResult DomainName="domain1.com" DomainID="151378" Renew="true" />
+  </CommandResponse>
+</ApiResponse>`
+
+	attempts := 0
+	mux.HandleFunc("/", failingHandler(1, statusHandler(http.StatusInternalServerError), renewXML, &attempts))
+	client.RetryPolicy = testRetryPolicy(3)
+
+	if _, err := client.DomainRenew("domain1.com", 1); err == nil {
+		t.Error("DomainRenew returned no error, want HTTP 500")
+	}
+	if attempts != 1 {
+		t.Errorf("server saw %d attempts of a non-idempotent command after a 500, want 1", attempts)
+	}
+
+	// A rate limited call was never executed, so it is safe to send again.
+	attempts = 0
+	mux = http.NewServeMux()
+	server.Config.Handler = mux
+	mux.HandleFunc("/", failingHandler(1, statusHandler(http.StatusTooManyRequests), renewXML, &attempts))
+
+	if _, err := client.DomainRenew("domain1.com", 1); err != nil {
+		t.Errorf("DomainRenew returned error: %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("server saw %d attempts of a rate limited command, want 2", attempts)
+	}
+
+	// Creating an address twice duplicates it and resetting a password twice
+	// sends two emails, so neither is retried after a server error.
+	calls := map[string]func() error{
+		"UsersAddressCreate": func() error {
+			address := office
+			_, err := client.UsersAddressCreate(&address)
+			return err
+		},
+		"UsersResetPassword": func() error {
+			_, err := client.UsersResetPassword("EMAILADDRESS", "user@example.com")
+			return err
+		},
+	}
+	for name, call := range calls {
+		attempts = 0
+		mux = http.NewServeMux()
+		server.Config.Handler = mux
+		mux.HandleFunc("/", failingHandler(1, statusHandler(http.StatusInternalServerError), renewXML, &attempts))
+
+		if err := call(); err == nil {
+			t.Errorf("%s returned no error, want HTTP 500", name)
+		}
+		if attempts != 1 {
+			t.Errorf("server saw %d attempts of %s after a 500, want 1", attempts, name)
+		}
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	policy := &RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	tests := []struct {
+		attempt  int
+		min, max time.Duration
+	}{
+		{1, 50 * time.Millisecond, 100 * time.Millisecond},
+		{2, 100 * time.Millisecond, 200 * time.Millisecond},
+		{3, 200 * time.Millisecond, 400 * time.Millisecond},
+		{10, 500 * time.Millisecond, time.Second},
+	}
+	for _, tt := range tests {
+		for i := 0; i < 20; i++ {
+			if d := policy.backoff(tt.attempt); d < tt.min || d > tt.max {
+				t.Errorf("backoff(%d) = %v, want between %v and %v", tt.attempt, d, tt.min, tt.max)
+			}
+		}
+	}
+}
@@ -0,0 +1,448 @@
+package namecheap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+const (
+	sslCreate               = "namecheap.ssl.create"
+	sslGetList              = "namecheap.ssl.getList"
+	sslGetInfo              = "namecheap.ssl.getInfo"
+	sslActivate             = "namecheap.ssl.activate"
+	sslParseCSR             = "namecheap.ssl.parseCSR"
+	sslGetApproverEmailList = "namecheap.ssl.getApproverEmailList"
+	sslResendApproverEmail  = "namecheap.ssl.resendApproverEmail"
+	sslReissue              = "namecheap.ssl.reissue"
+	sslRenew                = "namecheap.ssl.renew"
+	sslRevoke               = "namecheap.ssl.revokecertificate"
+)
+
+// SSLDCVMethod is the domain control validation method used when activating
+// or reissuing a certificate.
+type SSLDCVMethod string
+
+const (
+	SSLDCVEmail SSLDCVMethod = "EMAIL"
+	SSLDCVHTTP  SSLDCVMethod = "HTTP"
+	SSLDCVDNS   SSLDCVMethod = "DNS"
+)
+
+// SSLDCV selects how domain control is validated. ApproverEmail is only used
+// with SSLDCVEmail and must be one of the addresses returned by
+// SSLGetApproverEmailList.
+type SSLDCV struct {
+	Method        SSLDCVMethod
+	ApproverEmail string
+}
+
+func (dcv SSLDCV) addValues(u url.Values) error {
+	switch dcv.Method {
+	case SSLDCVEmail:
+		if dcv.ApproverEmail == "" {
+			return errors.New("ApproverEmail is required for email validation")
+		}
+		u.Set("ApproverEmail", dcv.ApproverEmail)
+	case SSLDCVHTTP:
+		u.Set("HTTPDCValidation", "true")
+	case SSLDCVDNS:
+		u.Set("DNSDCValidation", "true")
+	default:
+		return fmt.Errorf("unknown DCV method %q", dcv.Method)
+	}
+	return nil
+}
+
+type SSLCertificate struct {
+	CertificateID int    `xml:"CertificateID,attr"`
+	Created       string `xml:"Created,attr"`
+	SSLType       string `xml:"SSLType,attr"`
+	Years         int    `xml:"Years,attr"`
+	Status        string `xml:"Status,attr"`
+}
+
+// SSLCreateResult represents the data returned by 'ssl.create'
+type SSLCreateResult struct {
+	IsSuccess     bool             `xml:"IsSuccess,attr"`
+	OrderID       int              `xml:"OrderId,attr"`
+	TransactionID int              `xml:"TransactionId,attr"`
+	ChargedAmount float64          `xml:"ChargedAmount,attr"`
+	Certificates  []SSLCertificate `xml:"SSLCertificate"`
+}
+
+// SSLGetListResult represents the data returned by 'ssl.getList'
+type SSLGetListResult struct {
+	CertificateID        int    `xml:"CertificateID,attr"`
+	HostName             string `xml:"HostName,attr"`
+	SSLType              string `xml:"SSLType,attr"`
+	PurchaseDate         string `xml:"PurchaseDate,attr"`
+	ExpireDate           string `xml:"ExpireDate,attr"`
+	ActivationExpireDate string `xml:"ActivationExpireDate,attr"`
+	IsExpired            bool   `xml:"IsExpiredYN,attr"`
+	Status               string `xml:"Status,attr"`
+}
+
+// SSLInfo represents the data returned by 'ssl.getInfo'
+type SSLInfo struct {
+	Status               string `xml:"Status,attr"`
+	StatusDescription    string `xml:"StatusDescription,attr"`
+	Type                 string `xml:"Type,attr"`
+	IssuedOn             string `xml:"IssuedOn,attr"`
+	Expires              string `xml:"Expires,attr"`
+	ActivationExpireDate string `xml:"ActivationExpireDate,attr"`
+	OrderID              int    `xml:"OrderId,attr"`
+	ReplacedBy           int    `xml:"ReplacedBy,attr"`
+	SANSCount            int    `xml:"SANSCount,attr"`
+	CSR                  string `xml:"CertificateDetails>CSR"`
+	ApproverEmail        string `xml:"CertificateDetails>ApproverEmail"`
+	CommonName           string `xml:"CertificateDetails>CommonName"`
+	AdministratorName    string `xml:"CertificateDetails>AdministratorName"`
+	AdministratorEmail   string `xml:"CertificateDetails>AdministratorEmail"`
+	ProviderOrderID      string `xml:"Provider>OrderID"`
+	ProviderName         string `xml:"Provider>Name"`
+}
+
+// SSLHTTPDCV holds the file to serve for HTTP based validation.
+type SSLHTTPDCV struct {
+	Domain      string `xml:"domain,attr"`
+	FileName    string `xml:"FileName"`
+	FileContent string `xml:"FileContent"`
+}
+
+// SSLDNSDCV holds the CNAME record to create for DNS based validation.
+type SSLDNSDCV struct {
+	Domain   string `xml:"domain,attr"`
+	HostName string `xml:"HostName"`
+	Target   string `xml:"Target"`
+}
+
+// SSLActivateResult represents the data returned by 'ssl.activate' and 'ssl.reissue'
+type SSLActivateResult struct {
+	ID            int          `xml:"ID,attr"`
+	IsSuccess     bool         `xml:"IsSuccess,attr"`
+	HTTPDCV       []SSLHTTPDCV `xml:"HttpDCValidation>DNS"`
+	DNSDCV        []SSLDNSDCV  `xml:"DNSDCValidation>DNS"`
+	ApproverEmail string       `xml:"ApproverEmail"`
+}
+
+// SSLParseCSRResult represents the data returned by 'ssl.parseCSR'
+type SSLParseCSRResult struct {
+	CommonName       string `xml:"CSRDetails>CommonName"`
+	DomainName       string `xml:"CSRDetails>DomainName"`
+	Country          string `xml:"CSRDetails>Country"`
+	OrganisationUnit string `xml:"CSRDetails>OrganisationUnit"`
+	Organisation     string `xml:"CSRDetails>Organisation"`
+	ValidTrueDomain  bool   `xml:"CSRDetails>ValidTrueDomain"`
+	State            string `xml:"CSRDetails>State"`
+	Locality         string `xml:"CSRDetails>Locality"`
+	Email            string `xml:"CSRDetails>Email"`
+}
+
+// SSLApproverEmailListResult represents the data returned by 'ssl.getApproverEmailList'
+type SSLApproverEmailListResult struct {
+	Domain        string   `xml:"Domain,attr"`
+	DomainEmails  []string `xml:"Domainemails>email"`
+	GenericEmails []string `xml:"Genericemails>email"`
+	ManualEmails  []string `xml:"Manualemails>email"`
+}
+
+// SSLResendApproverEmailResult represents the data returned by 'ssl.resendApproverEmail'
+type SSLResendApproverEmailResult struct {
+	ID        int  `xml:"ID,attr"`
+	IsSuccess bool `xml:"IsSuccess,attr"`
+}
+
+// SSLRenewResult represents the data returned by 'ssl.renew'
+type SSLRenewResult struct {
+	CertificateID int     `xml:"CertificateID,attr"`
+	Years         int     `xml:"Years,attr"`
+	SSLType       string  `xml:"SSLType,attr"`
+	OrderID       int     `xml:"OrderId,attr"`
+	TransactionID int     `xml:"TransactionId,attr"`
+	ChargedAmount float64 `xml:"ChargedAmount,attr"`
+}
+
+// SSLRevokeResult represents the data returned by 'ssl.revokecertificate'
+type SSLRevokeResult struct {
+	ID        int  `xml:"ID,attr"`
+	IsSuccess bool `xml:"IsSuccess,attr"`
+}
+
+// SSLListOption filters and sorts the result of SSLGetList.
+// https://www.namecheap.com/support/api/methods/ssl/get-list.aspx
+type SSLListOption struct {
+	// ListType is one of ALL, Processing, EmailSent, TechnicalProblem,
+	// InProgress, Completed, Deactivated, Active, Cancelled, NewPurchase
+	// or NewRenewal.
+	ListType   string
+	SearchTerm string
+	// SortBy is one of PURCHASEDATE, SSLTYPE, EXPIREDATETIME or Host_Name,
+	// optionally with a _DESC suffix.
+	SortBy string
+}
+
+// SSLActivateOption holds the optional parameters of SSLActivate and SSLReissue.
+type SSLActivateOption struct {
+	WebServerType string
+}
+
+func (client *Client) SSLCreate(sslType string, years int) (*SSLCreateResult, error) {
+	return client.SSLCreateContext(context.Background(), sslType, years)
+}
+
+// SSLCreateContext is like SSLCreate but uses ctx for the underlying request.
+func (client *Client) SSLCreateContext(ctx context.Context, sslType string, years int) (*SSLCreateResult, error) {
+	if err := client.checkSpending(); err != nil {
+		return nil, err
+	}
+
+	requestInfo := &ApiRequest{
+		command: sslCreate,
+		method:  "POST",
+		params:  url.Values{},
+	}
+	requestInfo.params.Set("Type", sslType)
+	requestInfo.params.Set("Years", strconv.Itoa(years))
+
+	resp, err := client.do(ctx, requestInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.SSLCreate, nil
+}
+
+func (client *Client) SSLGetList(currentPage uint, pageSize uint, options ...SSLListOption) ([]SSLGetListResult, Paging, error) {
+	return client.SSLGetListContext(context.Background(), currentPage, pageSize, options...)
+}
+
+// SSLGetListContext is like SSLGetList but uses ctx for the underlying request.
+func (client *Client) SSLGetListContext(ctx context.Context, currentPage uint, pageSize uint, options ...SSLListOption) ([]SSLGetListResult, Paging, error) {
+	requestInfo := &ApiRequest{
+		command: sslGetList,
+		method:  "POST",
+		params:  url.Values{},
+	}
+	requestInfo.params.Set("Page", strconv.Itoa(int(ValidateCurrentPage(currentPage))))
+	requestInfo.params.Set("PageSize", strconv.Itoa(int(ValidatePageSize(pageSize))))
+	for _, opt := range options {
+		if opt.ListType != "" {
+			requestInfo.params.Set("ListType", opt.ListType)
+		}
+		if opt.SearchTerm != "" {
+			requestInfo.params.Set("SearchTerm", opt.SearchTerm)
+		}
+		if opt.SortBy != "" {
+			requestInfo.params.Set("SortBy", opt.SortBy)
+		}
+	}
+
+	resp, err := client.do(ctx, requestInfo)
+	if err != nil {
+		return nil, Paging{}, err
+	}
+
+	return resp.SSLList, Paging{TotalItems: resp.TotalItems, CurrentPage: resp.CurrentPage, PageSize: resp.PageSize}, nil
+}
+
+func (client *Client) SSLGetInfo(certificateID int) (*SSLInfo, error) {
+	return client.SSLGetInfoContext(context.Background(), certificateID)
+}
+
+// SSLGetInfoContext is like SSLGetInfo but uses ctx for the underlying request.
+func (client *Client) SSLGetInfoContext(ctx context.Context, certificateID int) (*SSLInfo, error) {
+	requestInfo := &ApiRequest{
+		command: sslGetInfo,
+		method:  "POST",
+		params:  url.Values{},
+	}
+	requestInfo.params.Set("CertificateID", strconv.Itoa(certificateID))
+
+	resp, err := client.do(ctx, requestInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.SSLInfo, nil
+}
+
+// SSLActivate submits the CSR for a newly purchased certificate and starts
+// domain control validation.
+func (client *Client) SSLActivate(certificateID int, csr, adminEmail string, dcv SSLDCV, options ...SSLActivateOption) (*SSLActivateResult, error) {
+	return client.SSLActivateContext(context.Background(), certificateID, csr, adminEmail, dcv, options...)
+}
+
+// SSLActivateContext is like SSLActivate but uses ctx for the underlying request.
+func (client *Client) SSLActivateContext(ctx context.Context, certificateID int, csr, adminEmail string, dcv SSLDCV, options ...SSLActivateOption) (*SSLActivateResult, error) {
+	requestInfo, err := newSSLActivateRequest(sslActivate, certificateID, csr, adminEmail, dcv, options)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.do(ctx, requestInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.SSLActivate, nil
+}
+
+// SSLReissue reissues a certificate with a new CSR, validating domain
+// control the same way as SSLActivate.
+func (client *Client) SSLReissue(certificateID int, csr, adminEmail string, dcv SSLDCV, options ...SSLActivateOption) (*SSLActivateResult, error) {
+	return client.SSLReissueContext(context.Background(), certificateID, csr, adminEmail, dcv, options...)
+}
+
+// SSLReissueContext is like SSLReissue but uses ctx for the underlying request.
+func (client *Client) SSLReissueContext(ctx context.Context, certificateID int, csr, adminEmail string, dcv SSLDCV, options ...SSLActivateOption) (*SSLActivateResult, error) {
+	requestInfo, err := newSSLActivateRequest(sslReissue, certificateID, csr, adminEmail, dcv, options)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.do(ctx, requestInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.SSLReissue, nil
+}
+
+func newSSLActivateRequest(command string, certificateID int, csr, adminEmail string, dcv SSLDCV, options []SSLActivateOption) (*ApiRequest, error) {
+	requestInfo := &ApiRequest{
+		command: command,
+		method:  "POST",
+		params:  url.Values{},
+	}
+	requestInfo.params.Set("CertificateID", strconv.Itoa(certificateID))
+	requestInfo.params.Set("CSR", csr)
+	requestInfo.params.Set("AdminEmailAddress", adminEmail)
+	if err := dcv.addValues(requestInfo.params); err != nil {
+		return nil, err
+	}
+	for _, opt := range options {
+		if opt.WebServerType != "" {
+			requestInfo.params.Set("WebServerType", opt.WebServerType)
+		}
+	}
+	return requestInfo, nil
+}
+
+func (client *Client) SSLParseCSR(csr, certificateType string) (*SSLParseCSRResult, error) {
+	return client.SSLParseCSRContext(context.Background(), csr, certificateType)
+}
+
+// SSLParseCSRContext is like SSLParseCSR but uses ctx for the underlying request.
+func (client *Client) SSLParseCSRContext(ctx context.Context, csr, certificateType string) (*SSLParseCSRResult, error) {
+	requestInfo := &ApiRequest{
+		command: sslParseCSR,
+		method:  "POST",
+		params:  url.Values{},
+	}
+	requestInfo.params.Set("csr", csr)
+	if certificateType != "" {
+		requestInfo.params.Set("CertificateType", certificateType)
+	}
+
+	resp, err := client.do(ctx, requestInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.SSLParseCSR, nil
+}
+
+func (client *Client) SSLGetApproverEmailList(domainName, certificateType string) (*SSLApproverEmailListResult, error) {
+	return client.SSLGetApproverEmailListContext(context.Background(), domainName, certificateType)
+}
+
+// SSLGetApproverEmailListContext is like SSLGetApproverEmailList but uses ctx for the underlying request.
+func (client *Client) SSLGetApproverEmailListContext(ctx context.Context, domainName, certificateType string) (*SSLApproverEmailListResult, error) {
+	requestInfo := &ApiRequest{
+		command: sslGetApproverEmailList,
+		method:  "POST",
+		params:  url.Values{},
+	}
+	requestInfo.params.Set("DomainName", domainName)
+	requestInfo.params.Set("CertificateType", certificateType)
+
+	resp, err := client.do(ctx, requestInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.SSLApproverEmailList, nil
+}
+
+func (client *Client) SSLResendApproverEmail(certificateID int) (*SSLResendApproverEmailResult, error) {
+	return client.SSLResendApproverEmailContext(context.Background(), certificateID)
+}
+
+// SSLResendApproverEmailContext is like SSLResendApproverEmail but uses ctx for the underlying request.
+func (client *Client) SSLResendApproverEmailContext(ctx context.Context, certificateID int) (*SSLResendApproverEmailResult, error) {
+	requestInfo := &ApiRequest{
+		command: sslResendApproverEmail,
+		method:  "POST",
+		params:  url.Values{},
+	}
+	requestInfo.params.Set("CertificateID", strconv.Itoa(certificateID))
+
+	resp, err := client.do(ctx, requestInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.SSLResendApproverEmail, nil
+}
+
+func (client *Client) SSLRenew(certificateID int, sslType string, years int) (*SSLRenewResult, error) {
+	return client.SSLRenewContext(context.Background(), certificateID, sslType, years)
+}
+
+// SSLRenewContext is like SSLRenew but uses ctx for the underlying request.
+func (client *Client) SSLRenewContext(ctx context.Context, certificateID int, sslType string, years int) (*SSLRenewResult, error) {
+	if err := client.checkSpending(); err != nil {
+		return nil, err
+	}
+
+	requestInfo := &ApiRequest{
+		command: sslRenew,
+		method:  "POST",
+		params:  url.Values{},
+	}
+	requestInfo.params.Set("CertificateID", strconv.Itoa(certificateID))
+	requestInfo.params.Set("SSLType", sslType)
+	requestInfo.params.Set("Years", strconv.Itoa(years))
+
+	resp, err := client.do(ctx, requestInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.SSLRenew, nil
+}
+
+func (client *Client) SSLRevoke(certificateID int, certificateType string) (*SSLRevokeResult, error) {
+	return client.SSLRevokeContext(context.Background(), certificateID, certificateType)
+}
+
+// SSLRevokeContext is like SSLRevoke but uses ctx for the underlying request.
+func (client *Client) SSLRevokeContext(ctx context.Context, certificateID int, certificateType string) (*SSLRevokeResult, error) {
+	requestInfo := &ApiRequest{
+		command: sslRevoke,
+		method:  "POST",
+		params:  url.Values{},
+	}
+	requestInfo.params.Set("CertificateID", strconv.Itoa(certificateID))
+	requestInfo.params.Set("CertificateType", certificateType)
+
+	resp, err := client.do(ctx, requestInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.SSLRevoke, nil
+}
@@ -0,0 +1,308 @@
+package namecheap
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestSSLCreate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	serveFixture(t, "ssl.create.xml", url.Values{
+		"Command": {"namecheap.ssl.create"},
+		"Type":    {"PositiveSSL"},
+		"Years":   {"1"},
+	})
+
+	result, err := client.SSLCreate("PositiveSSL", 1)
+	if err != nil {
+		t.Errorf("SSLCreate returned error: %v", err)
+	}
+	want := &SSLCreateResult{
+		IsSuccess:     true,
+		OrderID:       1542,
+		TransactionID: 1676,
+		ChargedAmount: 9,
+		Certificates: []SSLCertificate{{
+			CertificateID: 52556,
+			Created:       "10/24/2012",
+			SSLType:       "PositiveSSL",
+			Years:         1,
+			Status:        "NewPurchase",
+		}},
+	}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("SSLCreate returned %+v, want %+v", result, want)
+	}
+}
+
+func TestSSLGetList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	serveFixture(t, "ssl.getList.xml", url.Values{
+		"Command":    {"namecheap.ssl.getList"},
+		"Page":       {"1"},
+		"PageSize":   {"20"},
+		"ListType":   {"Active"},
+		"SearchTerm": {"example"},
+		"SortBy":     {"EXPIREDATETIME_DESC"},
+	})
+
+	certs, paging, err := client.SSLGetList(1, 20, SSLListOption{
+		ListType:   "Active",
+		SearchTerm: "example",
+		SortBy:     "EXPIREDATETIME_DESC",
+	})
+	if err != nil {
+		t.Errorf("SSLGetList returned error: %v", err)
+	}
+	want := []SSLGetListResult{
+		{
+			CertificateID:        52556,
+			SSLType:              "PositiveSSL",
+			PurchaseDate:         "10/24/2012",
+			ExpireDate:           "10/24/2013",
+			ActivationExpireDate: "01/22/2013",
+			Status:               "newpurchase",
+		},
+		{
+			CertificateID:        52557,
+			HostName:             "www.example.com",
+			SSLType:              "PositiveSSL",
+			PurchaseDate:         "10/24/2012",
+			ExpireDate:           "10/24/2013",
+			ActivationExpireDate: "01/22/2013",
+			Status:               "active",
+		},
+	}
+	if !reflect.DeepEqual(certs, want) {
+		t.Errorf("SSLGetList returned %+v, want %+v", certs, want)
+	}
+	if wantPaging := (Paging{TotalItems: 2, CurrentPage: 1, PageSize: 20}); paging != wantPaging {
+		t.Errorf("SSLGetList returned paging %+v, want %+v", paging, wantPaging)
+	}
+}
+
+func TestSSLGetInfo(t *testing.T) {
+	setup()
+	defer teardown()
+
+	serveFixture(t, "ssl.getInfo.xml", url.Values{
+		"Command":       {"namecheap.ssl.getInfo"},
+		"CertificateID": {"52557"},
+	})
+
+	info, err := client.SSLGetInfo(52557)
+	if err != nil {
+		t.Errorf("SSLGetInfo returned error: %v", err)
+	}
+	want := &SSLInfo{
+		Status:               "active",
+		StatusDescription:    "Certificate is Active",
+		Type:                 "PositiveSSL",
+		IssuedOn:             "10/25/2012",
+		Expires:              "10/24/2013",
+		ActivationExpireDate: "01/22/2013",
+		OrderID:              1542,
+		CSR:                  "-----BEGIN CERTIFICATE REQUEST-----MIIB...-----END CERTIFICATE REQUEST-----",
+		ApproverEmail:        "admin@example.com",
+		CommonName:           "www.example.com",
+		AdministratorName:    "John Smith",
+		AdministratorEmail:   "john@gmail.com",
+		ProviderOrderID:      "12345678",
+		ProviderName:         "COMODO",
+	}
+	if !reflect.DeepEqual(info, want) {
+		t.Errorf("SSLGetInfo returned %+v, want %+v", info, want)
+	}
+}
+
+func TestSSLActivate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	serveFixture(t, "ssl.activate.xml", url.Values{
+		"Command":           {"namecheap.ssl.activate"},
+		"CertificateID":     {"52556"},
+		"CSR":               {"a-csr"},
+		"AdminEmailAddress": {"john@gmail.com"},
+		"HTTPDCValidation":  {"true"},
+		"WebServerType":     {"nginx"},
+	})
+
+	result, err := client.SSLActivate(52556, "a-csr", "john@gmail.com", SSLDCV{Method: SSLDCVHTTP}, SSLActivateOption{
+		WebServerType: "nginx",
+	})
+	if err != nil {
+		t.Errorf("SSLActivate returned error: %v", err)
+	}
+	want := &SSLActivateResult{
+		ID:        52556,
+		IsSuccess: true,
+		HTTPDCV: []SSLHTTPDCV{{
+			Domain:      "www.example.com",
+			FileName:    "C7F0F6E6C7F0F6E6.txt",
+			FileContent: "6C6E0B3E59B8D0A3 comodoca.com",
+		}},
+	}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("SSLActivate returned %+v, want %+v", result, want)
+	}
+
+	if _, err := client.SSLActivate(52556, "a-csr", "john@gmail.com", SSLDCV{Method: SSLDCVEmail}); err == nil {
+		t.Error("SSLActivate with email validation and no approver returned no error")
+	}
+}
+
+func TestSSLReissue(t *testing.T) {
+	setup()
+	defer teardown()
+
+	serveFixture(t, "ssl.reissue.xml", url.Values{
+		"Command":           {"namecheap.ssl.reissue"},
+		"CertificateID":     {"52557"},
+		"CSR":               {"a-csr"},
+		"AdminEmailAddress": {"john@gmail.com"},
+		"ApproverEmail":     {"admin@example.com"},
+	})
+
+	result, err := client.SSLReissue(52557, "a-csr", "john@gmail.com", SSLDCV{
+		Method:        SSLDCVEmail,
+		ApproverEmail: "admin@example.com",
+	})
+	if err != nil {
+		t.Errorf("SSLReissue returned error: %v", err)
+	}
+	want := &SSLActivateResult{
+		ID:            52557,
+		IsSuccess:     true,
+		ApproverEmail: "admin@example.com",
+	}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("SSLReissue returned %+v, want %+v", result, want)
+	}
+}
+
+func TestSSLParseCSR(t *testing.T) {
+	setup()
+	defer teardown()
+
+	serveFixture(t, "ssl.parseCSR.xml", url.Values{
+		"Command":         {"namecheap.ssl.parseCSR"},
+		"csr":             {"a-csr"},
+		"CertificateType": {"PositiveSSL"},
+	})
+
+	result, err := client.SSLParseCSR("a-csr", "PositiveSSL")
+	if err != nil {
+		t.Errorf("SSLParseCSR returned error: %v", err)
+	}
+	want := &SSLParseCSRResult{
+		CommonName:       "www.example.com",
+		DomainName:       "example.com",
+		Country:          "US",
+		OrganisationUnit: "IT",
+		Organisation:     "Example Inc",
+		ValidTrueDomain:  true,
+		State:            "CA",
+		Locality:         "Los Angeles",
+		Email:            "john@gmail.com",
+	}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("SSLParseCSR returned %+v, want %+v", result, want)
+	}
+}
+
+func TestSSLGetApproverEmailList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	serveFixture(t, "ssl.getApproverEmailList.xml", url.Values{
+		"Command":         {"namecheap.ssl.getApproverEmailList"},
+		"DomainName":      {"example.com"},
+		"CertificateType": {"PositiveSSL"},
+	})
+
+	result, err := client.SSLGetApproverEmailList("example.com", "PositiveSSL")
+	if err != nil {
+		t.Errorf("SSLGetApproverEmailList returned error: %v", err)
+	}
+	want := &SSLApproverEmailListResult{
+		Domain:        "example.com",
+		DomainEmails:  []string{"john@gmail.com"},
+		GenericEmails: []string{"admin@example.com", "webmaster@example.com"},
+	}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("SSLGetApproverEmailList returned %+v, want %+v", result, want)
+	}
+}
+
+func TestSSLResendApproverEmail(t *testing.T) {
+	setup()
+	defer teardown()
+
+	serveFixture(t, "ssl.resendApproverEmail.xml", url.Values{
+		"Command":       {"namecheap.ssl.resendApproverEmail"},
+		"CertificateID": {"52556"},
+	})
+
+	result, err := client.SSLResendApproverEmail(52556)
+	if err != nil {
+		t.Errorf("SSLResendApproverEmail returned error: %v", err)
+	}
+	want := &SSLResendApproverEmailResult{ID: 52556, IsSuccess: true}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("SSLResendApproverEmail returned %+v, want %+v", result, want)
+	}
+}
+
+func TestSSLRenew(t *testing.T) {
+	setup()
+	defer teardown()
+
+	serveFixture(t, "ssl.renew.xml", url.Values{
+		"Command":       {"namecheap.ssl.renew"},
+		"CertificateID": {"52557"},
+		"SSLType":       {"PositiveSSL"},
+		"Years":         {"1"},
+	})
+
+	result, err := client.SSLRenew(52557, "PositiveSSL", 1)
+	if err != nil {
+		t.Errorf("SSLRenew returned error: %v", err)
+	}
+	want := &SSLRenewResult{
+		CertificateID: 52558,
+		Years:         1,
+		SSLType:       "PositiveSSL",
+		OrderID:       1601,
+		TransactionID: 1733,
+		ChargedAmount: 9,
+	}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("SSLRenew returned %+v, want %+v", result, want)
+	}
+}
+
+func TestSSLRevoke(t *testing.T) {
+	setup()
+	defer teardown()
+
+	serveFixture(t, "ssl.revokecertificate.xml", url.Values{
+		"Command":         {"namecheap.ssl.revokecertificate"},
+		"CertificateID":   {"52557"},
+		"CertificateType": {"PositiveSSL"},
+	})
+
+	result, err := client.SSLRevoke(52557, "PositiveSSL")
+	if err != nil {
+		t.Errorf("SSLRevoke returned error: %v", err)
+	}
+	want := &SSLRevokeResult{ID: 52557, IsSuccess: true}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("SSLRevoke returned %+v, want %+v", result, want)
+	}
+}
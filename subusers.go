@@ -0,0 +1,225 @@
+package namecheap
+
+import (
+	"context"
+	"net/url"
+)
+
+const (
+	usersCreate         = "namecheap.users.create"
+	usersUpdate         = "namecheap.users.update"
+	usersChangePassword = "namecheap.users.changePassword"
+	usersResetPassword  = "namecheap.users.resetPassword"
+	usersLogin          = "namecheap.users.login"
+	// User `FindBy` for 'users.resetPassword'
+	// https://www.namecheap.com/support/api/methods/users/reset-password.aspx
+	RESET_BY_EMAIL    = "EMAILADDRESS"
+	RESET_BY_DOMAIN   = "DOMAINNAME"
+	RESET_BY_USERNAME = "USERNAME"
+)
+
+type userNameContextKey struct{}
+
+// WithUserName returns a copy of ctx that makes any request issued with it
+// run as userName instead of Client.UserName. The ApiUser credentials are
+// unchanged, so userName must be a sub-user of the ApiUser.
+func WithUserName(ctx context.Context, userName string) context.Context {
+	return context.WithValue(ctx, userNameContextKey{}, userName)
+}
+
+// userName picks the UserName for a request: a per-call override from ctx,
+// then Client.UserName.
+func (client *Client) userName(ctx context.Context) string {
+	if userName, _ := ctx.Value(userNameContextKey{}).(string); userName != "" {
+		return userName
+	}
+	return client.UserName
+}
+
+// AsUser returns a copy of the client that runs every command as userName
+// with the same ApiUser credentials. The copy shares the HTTP client, retry
+// policy and rate limiter with the original.
+func (client *Client) AsUser(userName string) *Client {
+	derived := *client
+	derived.UserName = userName
+	return &derived
+}
+
+// UserProfile holds the account details sent by 'users.create' and 'users.update'.
+type UserProfile struct {
+	FirstName     string
+	LastName      string
+	JobTitle      string
+	Organization  string
+	Address1      string
+	Address2      string
+	City          string
+	StateProvince string
+	Zip           string
+	Country       string
+	EmailAddress  string
+	Phone         string
+	PhoneExt      string
+	Fax           string
+}
+
+// addValues adds the profile fields to the passed in url.Values, leaving out
+// the optional ones that are empty.
+func (profile *UserProfile) addValues(u url.Values) {
+	u.Set("FirstName", profile.FirstName)
+	u.Set("LastName", profile.LastName)
+	u.Set("Address1", profile.Address1)
+	u.Set("City", profile.City)
+	u.Set("StateProvince", profile.StateProvince)
+	u.Set("Zip", profile.Zip)
+	u.Set("Country", profile.Country)
+	u.Set("EmailAddress", profile.EmailAddress)
+	u.Set("Phone", profile.Phone)
+	for name, value := range map[string]string{
+		"JobTitle":     profile.JobTitle,
+		"Organization": profile.Organization,
+		"Address2":     profile.Address2,
+		"PhoneExt":     profile.PhoneExt,
+		"Fax":          profile.Fax,
+	} {
+		if value != "" {
+			u.Set(name, value)
+		}
+	}
+}
+
+// UsersResult represents the data returned by 'users.create', 'users.update'
+// and 'users.changePassword'
+type UsersResult struct {
+	Success bool `xml:"Success,attr"`
+	UserID  int  `xml:"UserId,attr"`
+}
+
+// UsersResetPasswordResult represents the data returned by 'users.resetPassword'
+type UsersResetPasswordResult struct {
+	Success bool `xml:"Success,attr"`
+}
+
+// UsersLoginResult represents the data returned by 'users.login'
+type UsersLoginResult struct {
+	Success  bool   `xml:"Success,attr"`
+	UserName string `xml:"UserName,attr"`
+}
+
+// UsersCreate creates a new account under the ApiUser.
+func (client *Client) UsersCreate(newUserName, newUserPassword string, profile *UserProfile) (*UsersResult, error) {
+	return client.UsersCreateContext(context.Background(), newUserName, newUserPassword, profile)
+}
+
+// UsersCreateContext is like UsersCreate but uses ctx for the underlying request.
+func (client *Client) UsersCreateContext(ctx context.Context, newUserName, newUserPassword string, profile *UserProfile) (*UsersResult, error) {
+	requestInfo := &ApiRequest{
+		command: usersCreate,
+		method:  "POST",
+		params:  url.Values{},
+	}
+	requestInfo.params.Set("NewUserName", newUserName)
+	requestInfo.params.Set("NewUserPassword", newUserPassword)
+	requestInfo.params.Set("AcceptTerms", "1")
+	requestInfo.params.Set("AcceptNews", "0")
+	profile.addValues(requestInfo.params)
+
+	resp, err := client.do(ctx, requestInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.UsersCreate, nil
+}
+
+// UsersUpdate replaces the account details of the user the client runs as.
+func (client *Client) UsersUpdate(profile *UserProfile) (*UsersResult, error) {
+	return client.UsersUpdateContext(context.Background(), profile)
+}
+
+// UsersUpdateContext is like UsersUpdate but uses ctx for the underlying request.
+func (client *Client) UsersUpdateContext(ctx context.Context, profile *UserProfile) (*UsersResult, error) {
+	requestInfo := &ApiRequest{
+		command: usersUpdate,
+		method:  "POST",
+		params:  url.Values{},
+	}
+	profile.addValues(requestInfo.params)
+
+	resp, err := client.do(ctx, requestInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.UsersUpdate, nil
+}
+
+// UsersChangePassword changes the password of the user the client runs as.
+func (client *Client) UsersChangePassword(oldPassword, newPassword string) (*UsersResult, error) {
+	return client.UsersChangePasswordContext(context.Background(), oldPassword, newPassword)
+}
+
+// UsersChangePasswordContext is like UsersChangePassword but uses ctx for the underlying request.
+func (client *Client) UsersChangePasswordContext(ctx context.Context, oldPassword, newPassword string) (*UsersResult, error) {
+	requestInfo := &ApiRequest{
+		command: usersChangePassword,
+		method:  "POST",
+		params:  url.Values{},
+	}
+	requestInfo.params.Set("OldPassword", oldPassword)
+	requestInfo.params.Set("NewPassword", newPassword)
+
+	resp, err := client.do(ctx, requestInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.UsersChangePassword, nil
+}
+
+// UsersResetPassword emails a password reset link to the user found by
+// findBy (RESET_BY_EMAIL, RESET_BY_DOMAIN or RESET_BY_USERNAME).
+func (client *Client) UsersResetPassword(findBy, findByValue string) (*UsersResetPasswordResult, error) {
+	return client.UsersResetPasswordContext(context.Background(), findBy, findByValue)
+}
+
+// UsersResetPasswordContext is like UsersResetPassword but uses ctx for the underlying request.
+func (client *Client) UsersResetPasswordContext(ctx context.Context, findBy, findByValue string) (*UsersResetPasswordResult, error) {
+	requestInfo := &ApiRequest{
+		command: usersResetPassword,
+		method:  "POST",
+		params:  url.Values{},
+	}
+	requestInfo.params.Set("FindBy", findBy)
+	requestInfo.params.Set("FindByValue", findByValue)
+
+	resp, err := client.do(ctx, requestInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.UsersResetPassword, nil
+}
+
+// UsersLogin checks password against the account of the user the client
+// runs as. Only accounts created with UsersCreate can be validated.
+func (client *Client) UsersLogin(password string) (*UsersLoginResult, error) {
+	return client.UsersLoginContext(context.Background(), password)
+}
+
+// UsersLoginContext is like UsersLogin but uses ctx for the underlying request.
+func (client *Client) UsersLoginContext(ctx context.Context, password string) (*UsersLoginResult, error) {
+	requestInfo := &ApiRequest{
+		command: usersLogin,
+		method:  "POST",
+		params:  url.Values{},
+	}
+	requestInfo.params.Set("Password", password)
+
+	resp, err := client.do(ctx, requestInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.UsersLogin, nil
+}
@@ -0,0 +1,224 @@
+package namecheap
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+var teamProfile = &UserProfile{
+	FirstName:     "John",
+	LastName:      "Smith",
+	Organization:  "Platform Team",
+	Address1:      "8939 S.cross Blvd",
+	City:          "CA",
+	StateProvince: "CA",
+	Zip:           "90045",
+	Country:       "US",
+	EmailAddress:  "john@gmail.com",
+	Phone:         "+1.6613102107",
+}
+
+func fillProfileParams(p url.Values) url.Values {
+	p.Set("FirstName", "John")
+	p.Set("LastName", "Smith")
+	p.Set("Organization", "Platform Team")
+	p.Set("Address1", "8939 S.cross Blvd")
+	p.Set("City", "CA")
+	p.Set("StateProvince", "CA")
+	p.Set("Zip", "90045")
+	p.Set("Country", "US")
+	p.Set("EmailAddress", "john@gmail.com")
+	p.Set("Phone", "+1.6613102107")
+	return p
+}
+
+func TestUsersCreate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	respXML := `<?xml version="1.0" encoding="UTF-8"?>
+<ApiResponse xmlns="http://api.namecheap.com/xml.response" Status="OK">
+  <Errors />
+  <RequestedCommand>namecheap.users.create</RequestedCommand>
+  <CommandResponse Type="namecheap.users.create">
+    <UserCreateResult Success="true" UserId="4471" />
+  </CommandResponse>
+  <Server>SERVER-NAME</Server>
+  <GMTTimeDifference>+5</GMTTimeDifference>
+  <ExecutionTime>0.024</ExecutionTime>
+</ApiResponse>`
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		correctParams := fillProfileParams(fillDefaultParams(url.Values{}))
+		correctParams.Set("Command", "namecheap.users.create")
+		correctParams.Set("NewUserName", "platform")
+		correctParams.Set("NewUserPassword", "s3cr3t")
+		correctParams.Set("AcceptTerms", "1")
+		correctParams.Set("AcceptNews", "0")
+		testBody(t, r, correctParams)
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, respXML)
+	})
+
+	result, err := client.UsersCreate("platform", "s3cr3t", teamProfile)
+	if err != nil {
+		t.Errorf("UsersCreate returned error: %v", err)
+	}
+	want := &UsersResult{Success: true, UserID: 4471}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("UsersCreate returned %+v, want %+v", result, want)
+	}
+}
+
+func TestUsersUpdateAsUser(t *testing.T) {
+	setup()
+	defer teardown()
+
+	respXML := `<?xml version="1.0" encoding="UTF-8"?>
+<ApiResponse xmlns="http://api.namecheap.com/xml.response" Status="OK">
+  <Errors />
+  <RequestedCommand>namecheap.users.update</RequestedCommand>
+  <CommandResponse Type="namecheap.users.update">
+    <UserUpdateResult Success="true" UserId="4471" />
+  </CommandResponse>
+  <Server>SERVER-NAME</Server>
+  <GMTTimeDifference>+5</GMTTimeDifference>
+  <ExecutionTime>0.024</ExecutionTime>
+</ApiResponse>`
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		correctParams := fillProfileParams(fillDefaultParams(url.Values{}))
+		correctParams.Set("Command", "namecheap.users.update")
+		correctParams.Set("UserName", "platform")
+		testBody(t, r, correctParams)
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, respXML)
+	})
+
+	platform := client.AsUser("platform")
+	result, err := platform.UsersUpdate(teamProfile)
+	if err != nil {
+		t.Errorf("UsersUpdate returned error: %v", err)
+	}
+	want := &UsersResult{Success: true, UserID: 4471}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("UsersUpdate returned %+v, want %+v", result, want)
+	}
+	if client.UserName != "anUser" {
+		t.Errorf("AsUser changed the original client's UserName to %q", client.UserName)
+	}
+}
+
+func TestUsersChangePassword(t *testing.T) {
+	setup()
+	defer teardown()
+
+	respXML := `<?xml version="1.0" encoding="UTF-8"?>
+<ApiResponse xmlns="http://api.namecheap.com/xml.response" Status="OK">
+  <Errors />
+  <RequestedCommand>namecheap.users.changePassword</RequestedCommand>
+  <CommandResponse Type="namecheap.users.changePassword">
+    <UserChangePasswordResult Success="true" UserId="4471" />
+  </CommandResponse>
+  <Server>SERVER-NAME</Server>
+  <GMTTimeDifference>+5</GMTTimeDifference>
+  <ExecutionTime>0.024</ExecutionTime>
+</ApiResponse>`
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		correctParams := fillDefaultParams(url.Values{})
+		correctParams.Set("Command", "namecheap.users.changePassword")
+		correctParams.Set("UserName", "platform")
+		correctParams.Set("OldPassword", "s3cr3t")
+		correctParams.Set("NewPassword", "n3w-s3cr3t")
+		testBody(t, r, correctParams)
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, respXML)
+	})
+
+	ctx := WithUserName(context.Background(), "platform")
+	result, err := client.UsersChangePasswordContext(ctx, "s3cr3t", "n3w-s3cr3t")
+	if err != nil {
+		t.Errorf("UsersChangePassword returned error: %v", err)
+	}
+	want := &UsersResult{Success: true, UserID: 4471}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("UsersChangePassword returned %+v, want %+v", result, want)
+	}
+}
+
+func TestUsersResetPassword(t *testing.T) {
+	setup()
+	defer teardown()
+
+	respXML := `<?xml version="1.0" encoding="UTF-8"?>
+<ApiResponse xmlns="http://api.namecheap.com/xml.response" Status="OK">
+  <Errors />
+  <RequestedCommand>namecheap.users.resetPassword</RequestedCommand>
+  <CommandResponse Type="namecheap.users.resetPassword">
+    <UserResetPasswordResult Success="true" />
+  </CommandResponse>
+  <Server>SERVER-NAME</Server>
+  <GMTTimeDifference>+5</GMTTimeDifference>
+  <ExecutionTime>0.024</ExecutionTime>
+</ApiResponse>`
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		correctParams := fillDefaultParams(url.Values{})
+		correctParams.Set("Command", "namecheap.users.resetPassword")
+		correctParams.Set("FindBy", "EMAILADDRESS")
+		correctParams.Set("FindByValue", "john@gmail.com")
+		testBody(t, r, correctParams)
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, respXML)
+	})
+
+	result, err := client.UsersResetPassword(RESET_BY_EMAIL, "john@gmail.com")
+	if err != nil {
+		t.Errorf("UsersResetPassword returned error: %v", err)
+	}
+	want := &UsersResetPasswordResult{Success: true}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("UsersResetPassword returned %+v, want %+v", result, want)
+	}
+}
+
+func TestUsersLogin(t *testing.T) {
+	setup()
+	defer teardown()
+
+	respXML := `<?xml version="1.0" encoding="UTF-8"?>
+<ApiResponse xmlns="http://api.namecheap.com/xml.response" Status="OK">
+  <Errors />
+  <RequestedCommand>namecheap.users.login</RequestedCommand>
+  <CommandResponse Type="namecheap.users.login">
+    <UserLoginResult Success="true" UserName="platform" />
+  </CommandResponse>
+  <Server>SERVER-NAME</Server>
+  <GMTTimeDifference>+5</GMTTimeDifference>
+  <ExecutionTime>0.024</ExecutionTime>
+</ApiResponse>`
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		correctParams := fillDefaultParams(url.Values{})
+		correctParams.Set("Command", "namecheap.users.login")
+		correctParams.Set("UserName", "platform")
+		correctParams.Set("Password", "s3cr3t")
+		testBody(t, r, correctParams)
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, respXML)
+	})
+
+	result, err := client.AsUser("platform").UsersLogin("s3cr3t")
+	if err != nil {
+		t.Errorf("UsersLogin returned error: %v", err)
+	}
+	want := &UsersLoginResult{Success: true, UserName: "platform"}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("UsersLogin returned %+v, want %+v", result, want)
+	}
+}
@@ -0,0 +1,216 @@
+package namecheap
+
+import (
+	"context"
+	"encoding/xml"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	domainsTransferCreate       = "namecheap.domains.transfer.create"
+	domainsTransferGetStatus    = "namecheap.domains.transfer.getStatus"
+	domainsTransferUpdateStatus = "namecheap.domains.transfer.updateStatus"
+	domainsTransferGetList      = "namecheap.domains.transfer.getList"
+)
+
+// TransferStatus is the overall state of a transfer. It doubles as the
+// `ListType` filter of 'domains.transfer.getList', where TransferAll is also
+// accepted.
+// https://www.namecheap.com/support/api/methods/domains-transfer/get-list.aspx
+type TransferStatus string
+
+const (
+	TransferAll        TransferStatus = "ALL"
+	TransferInProgress TransferStatus = "INPROGRESS"
+	TransferCancelled  TransferStatus = "CANCELLED"
+	TransferCompleted  TransferStatus = "COMPLETED"
+)
+
+// UnmarshalXMLAttr normalises the status, which Namecheap returns in mixed
+// case (e.g. "Cancelled"), so it can be compared with the constants.
+func (status *TransferStatus) UnmarshalXMLAttr(attr xml.Attr) error {
+	*status = TransferStatus(strings.ToUpper(attr.Value))
+	return nil
+}
+
+// TransferStatusID is the detailed status code of a transfer. Status gives
+// the overall state and, in 'domains.transfer.getList', StatusDescription a
+// description of codes without a constant here.
+type TransferStatusID int
+
+const (
+	TransferStatusIDCancelled  TransferStatusID = -202 // transfer cancelled
+	TransferStatusIDCreated    TransferStatusID = -1   // order placed, transfer not started
+	TransferStatusIDCompleted  TransferStatusID = 5    // domain transferred successfully
+	TransferStatusIDInProgress TransferStatusID = 20   // transfer in progress
+)
+
+func (id TransferStatusID) String() string {
+	switch id {
+	case TransferStatusIDCancelled:
+		return "cancelled"
+	case TransferStatusIDCreated:
+		return "created"
+	case TransferStatusIDCompleted:
+		return "completed"
+	case TransferStatusIDInProgress:
+		return "in progress"
+	}
+	return "TransferStatusID(" + strconv.Itoa(int(id)) + ")"
+}
+
+// DomainTransferCreateResult represents the data returned by 'domains.transfer.create'
+type DomainTransferCreateResult struct {
+	DomainName    string           `xml:"DomainName,attr"`
+	Transfer      bool             `xml:"Transfer,attr"`
+	TransferID    int              `xml:"TransferID,attr"`
+	StatusID      TransferStatusID `xml:"StatusID,attr"`
+	OrderID       int              `xml:"OrderID,attr"`
+	TransactionID int              `xml:"TransactionID,attr"`
+	ChargedAmount float64          `xml:"ChargedAmount,attr"`
+}
+
+// DomainTransferGetStatusResult represents the data returned by 'domains.transfer.getStatus'
+type DomainTransferGetStatusResult struct {
+	TransferID int              `xml:"TransferID,attr"`
+	Status     TransferStatus   `xml:"Status,attr"`
+	StatusID   TransferStatusID `xml:"StatusID,attr"`
+}
+
+// DomainTransferUpdateStatusResult represents the data returned by 'domains.transfer.updateStatus'
+type DomainTransferUpdateStatusResult struct {
+	TransferID int  `xml:"TransferID,attr"`
+	Resubmit   bool `xml:"Resubmit,attr"`
+}
+
+// TransferGetListResult represents the data returned by 'domains.transfer.getList'
+type TransferGetListResult struct {
+	ID                int              `xml:"ID,attr"`
+	DomainName        string           `xml:"DomainName,attr"`
+	User              string           `xml:"User,attr"`
+	TransferDate      string           `xml:"TransferDate,attr"`
+	OrderID           int              `xml:"OrderID,attr"`
+	StatusID          TransferStatusID `xml:"StatusID,attr"`
+	Status            TransferStatus   `xml:"Status,attr"`
+	StatusDate        string           `xml:"StatusDate,attr"`
+	StatusDescription string           `xml:"StatusDescription,attr"`
+}
+
+type TransferCreateOption struct {
+	AddFreeWhoisguard bool
+	WGEnabled         bool
+}
+
+// TransferCreate starts the transfer of domainName to Namecheap using the
+// EPP (authorization) code from the losing registrar.
+func (client *Client) TransferCreate(domainName string, years int, eppCode string, options ...TransferCreateOption) (*DomainTransferCreateResult, error) {
+	return client.TransferCreateContext(context.Background(), domainName, years, eppCode, options...)
+}
+
+// TransferCreateContext is like TransferCreate but uses ctx for the underlying request.
+func (client *Client) TransferCreateContext(ctx context.Context, domainName string, years int, eppCode string, options ...TransferCreateOption) (*DomainTransferCreateResult, error) {
+	if err := client.checkSpending(); err != nil {
+		return nil, err
+	}
+
+	requestInfo := &ApiRequest{
+		command: domainsTransferCreate,
+		method:  "POST",
+		params:  url.Values{},
+	}
+	requestInfo.params.Set("DomainName", domainName)
+	requestInfo.params.Set("Years", strconv.Itoa(years))
+	requestInfo.params.Set("EPPCode", eppCode)
+	for _, opt := range options {
+		if opt.AddFreeWhoisguard {
+			requestInfo.params.Set("AddFreeWhoisguard", "yes")
+		}
+		if opt.WGEnabled {
+			requestInfo.params.Set("WGEnable", "yes")
+		}
+	}
+
+	resp, err := client.do(ctx, requestInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.DomainTransferCreate, nil
+}
+
+func (client *Client) TransferGetStatus(transferID int) (*DomainTransferGetStatusResult, error) {
+	return client.TransferGetStatusContext(context.Background(), transferID)
+}
+
+// TransferGetStatusContext is like TransferGetStatus but uses ctx for the underlying request.
+func (client *Client) TransferGetStatusContext(ctx context.Context, transferID int) (*DomainTransferGetStatusResult, error) {
+	requestInfo := &ApiRequest{
+		command: domainsTransferGetStatus,
+		method:  "POST",
+		params:  url.Values{},
+	}
+	requestInfo.params.Set("TransferID", strconv.Itoa(transferID))
+
+	resp, err := client.do(ctx, requestInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.DomainTransferGetStatus, nil
+}
+
+// TransferUpdateStatus resubmits a transfer that failed, for example after
+// the registry lock has been released at the losing registrar.
+func (client *Client) TransferUpdateStatus(transferID int) (*DomainTransferUpdateStatusResult, error) {
+	return client.TransferUpdateStatusContext(context.Background(), transferID)
+}
+
+// TransferUpdateStatusContext is like TransferUpdateStatus but uses ctx for the underlying request.
+func (client *Client) TransferUpdateStatusContext(ctx context.Context, transferID int) (*DomainTransferUpdateStatusResult, error) {
+	requestInfo := &ApiRequest{
+		command: domainsTransferUpdateStatus,
+		method:  "POST",
+		params:  url.Values{},
+	}
+	requestInfo.params.Set("TransferID", strconv.Itoa(transferID))
+	requestInfo.params.Set("Resubmit", "true")
+
+	resp, err := client.do(ctx, requestInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.DomainTransferUpdateStatus, nil
+}
+
+// TransferGetList returns one page of transfers in the given state. An empty
+// listType lists all transfers. currentPage and pageSize are clamped like in
+// DomainsGetList.
+func (client *Client) TransferGetList(listType TransferStatus, currentPage uint, pageSize uint) ([]TransferGetListResult, Paging, error) {
+	return client.TransferGetListContext(context.Background(), listType, currentPage, pageSize)
+}
+
+// TransferGetListContext is like TransferGetList but uses ctx for the underlying request.
+func (client *Client) TransferGetListContext(ctx context.Context, listType TransferStatus, currentPage uint, pageSize uint) ([]TransferGetListResult, Paging, error) {
+	if listType == "" {
+		listType = TransferAll
+	}
+
+	requestInfo := &ApiRequest{
+		command: domainsTransferGetList,
+		method:  "POST",
+		params:  url.Values{},
+	}
+	requestInfo.params.Set("ListType", string(listType))
+	requestInfo.params.Set("Page", strconv.Itoa(int(ValidateCurrentPage(currentPage))))
+	requestInfo.params.Set("PageSize", strconv.Itoa(int(ValidatePageSize(pageSize))))
+
+	resp, err := client.do(ctx, requestInfo)
+	if err != nil {
+		return nil, Paging{}, err
+	}
+
+	return resp.Transfers, Paging{TotalItems: resp.TotalItems, CurrentPage: resp.CurrentPage, PageSize: resp.PageSize}, nil
+}
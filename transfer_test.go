@@ -0,0 +1,224 @@
+package namecheap
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestTransferCreate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	respXML := `<?xml version="1.0" encoding="UTF-8"?>
+<ApiResponse xmlns="http://api.namecheap.com/xml.response" Status="OK">
+  <Errors />
+  <RequestedCommand>namecheap.domains.transfer.create</RequestedCommand>
+  <CommandResponse Type="namecheap.domains.transfer.create">
+    <DomainTransferCreateResult DomainName="domain1.com" Transfer="true" TransferID="23569" StatusID="-1" OrderID="35975" TransactionID="39341" ChargedAmount="9.0200" />
+  </CommandResponse>
+  <Server>SERVER-NAME</Server>
+  <GMTTimeDifference>+5</GMTTimeDifference>
+  <ExecutionTime>0.078</ExecutionTime>
+</ApiResponse>`
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		correctParams := fillDefaultParams(url.Values{})
+		correctParams.Set("Command", "namecheap.domains.transfer.create")
+		correctParams.Set("DomainName", "domain1.com")
+		correctParams.Set("Years", "1")
+		correctParams.Set("EPPCode", "s3cr3t-epp")
+		correctParams.Set("AddFreeWhoisguard", "yes")
+		correctParams.Set("WGEnable", "yes")
+		testBody(t, r, correctParams)
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, respXML)
+	})
+
+	result, err := client.TransferCreate("domain1.com", 1, "s3cr3t-epp", TransferCreateOption{
+		AddFreeWhoisguard: true,
+		WGEnabled:         true,
+	})
+	if err != nil {
+		t.Errorf("TransferCreate returned error: %v", err)
+	}
+	want := &DomainTransferCreateResult{
+		DomainName:    "domain1.com",
+		Transfer:      true,
+		TransferID:    23569,
+		StatusID:      TransferStatusIDCreated,
+		OrderID:       35975,
+		TransactionID: 39341,
+		ChargedAmount: 9.02,
+	}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("TransferCreate returned %+v, want %+v", result, want)
+	}
+}
+
+func TestTransferGetStatus(t *testing.T) {
+	setup()
+	defer teardown()
+
+	respXML := `<?xml version="1.0" encoding="UTF-8"?>
+<ApiResponse xmlns="http://api.namecheap.com/xml.response" Status="OK">
+  <Errors />
+  <RequestedCommand>namecheap.domains.transfer.getStatus</RequestedCommand>
+  <CommandResponse Type="namecheap.domains.transfer.getStatus">
+    <DomainTransferGetStatusResult TransferID="15" Status="Cancelled" StatusID="-202" />
+  </CommandResponse>
+  <Server>SERVER-NAME</Server>
+  <GMTTimeDifference>+5</GMTTimeDifference>
+  <ExecutionTime>0.078</ExecutionTime>
+</ApiResponse>`
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		correctParams := fillDefaultParams(url.Values{})
+		correctParams.Set("Command", "namecheap.domains.transfer.getStatus")
+		correctParams.Set("TransferID", "15")
+		testBody(t, r, correctParams)
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, respXML)
+	})
+
+	result, err := client.TransferGetStatus(15)
+	if err != nil {
+		t.Errorf("TransferGetStatus returned error: %v", err)
+	}
+	want := &DomainTransferGetStatusResult{
+		TransferID: 15,
+		Status:     TransferCancelled,
+		StatusID:   TransferStatusIDCancelled,
+	}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("TransferGetStatus returned %+v, want %+v", result, want)
+	}
+}
+
+func TestTransferUpdateStatus(t *testing.T) {
+	setup()
+	defer teardown()
+
+	respXML := `<?xml version="1.0" encoding="UTF-8"?>
+<ApiResponse xmlns="http://api.namecheap.com/xml.response" Status="OK">
+  <Errors />
+  <RequestedCommand>namecheap.domains.transfer.updateStatus</RequestedCommand>
+  <CommandResponse Type="namecheap.domains.transfer.updateStatus">
+    <DomainTransferUpdateStatusResult TransferID="15" Resubmit="true" />
+  </CommandResponse>
+  <Server>SERVER-NAME</Server>
+  <GMTTimeDifference>+5</GMTTimeDifference>
+  <ExecutionTime>0.078</ExecutionTime>
+</ApiResponse>`
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		correctParams := fillDefaultParams(url.Values{})
+		correctParams.Set("Command", "namecheap.domains.transfer.updateStatus")
+		correctParams.Set("TransferID", "15")
+		correctParams.Set("Resubmit", "true")
+		testBody(t, r, correctParams)
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, respXML)
+	})
+
+	result, err := client.TransferUpdateStatus(15)
+	if err != nil {
+		t.Errorf("TransferUpdateStatus returned error: %v", err)
+	}
+	want := &DomainTransferUpdateStatusResult{
+		TransferID: 15,
+		Resubmit:   true,
+	}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("TransferUpdateStatus returned %+v, want %+v", result, want)
+	}
+}
+
+func TestTransferGetList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	respXML := `<?xml version="1.0" encoding="UTF-8"?>
+<ApiResponse xmlns="http://api.namecheap.com/xml.response" Status="OK">
+  <Errors />
+  <RequestedCommand>namecheap.domains.transfer.getList</RequestedCommand>
+  <CommandResponse Type="namecheap.domains.transfer.getList">
+    <TransferGetListResult>
+      <Transfer ID="25" DomainName="domain1.com" User="anUser" TransferDate="11/5/2008" OrderID="1234" StatusID="20" Status="INPROGRESS" StatusDate="11/5/2008" StatusDescription="Transfer in progress" />
+      <Transfer ID="26" DomainName="domain2.com" User="anUser" TransferDate="11/6/2008" OrderID="1235" StatusID="5" Status="Completed" StatusDate="11/12/2008" StatusDescription="Domain transferred successfully" />
+    </TransferGetListResult>
+    <Paging>
+      <TotalItems>42</TotalItems>
+      <CurrentPage>2</CurrentPage>
+      <PageSize>20</PageSize>
+    </Paging>
+  </CommandResponse>
+  <Server>SERVER-NAME</Server>
+  <GMTTimeDifference>+5</GMTTimeDifference>
+  <ExecutionTime>0.078</ExecutionTime>
+</ApiResponse>`
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		correctParams := fillDefaultParams(url.Values{})
+		correctParams.Set("Command", "namecheap.domains.transfer.getList")
+		correctParams.Set("ListType", "ALL")
+		correctParams.Set("Page", "2")
+		correctParams.Set("PageSize", "20")
+		testBody(t, r, correctParams)
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, respXML)
+	})
+
+	transfers, paging, err := client.TransferGetList("", 2, 20)
+	if err != nil {
+		t.Errorf("TransferGetList returned error: %v", err)
+	}
+	want := []TransferGetListResult{
+		{
+			ID:                25,
+			DomainName:        "domain1.com",
+			User:              "anUser",
+			TransferDate:      "11/5/2008",
+			OrderID:           1234,
+			StatusID:          TransferStatusIDInProgress,
+			Status:            TransferInProgress,
+			StatusDate:        "11/5/2008",
+			StatusDescription: "Transfer in progress",
+		},
+		{
+			ID:                26,
+			DomainName:        "domain2.com",
+			User:              "anUser",
+			TransferDate:      "11/6/2008",
+			OrderID:           1235,
+			StatusID:          TransferStatusIDCompleted,
+			Status:            TransferCompleted,
+			StatusDate:        "11/12/2008",
+			StatusDescription: "Domain transferred successfully",
+		},
+	}
+	if !reflect.DeepEqual(transfers, want) {
+		t.Errorf("TransferGetList returned %+v, want %+v", transfers, want)
+	}
+	wantPaging := Paging{TotalItems: 42, CurrentPage: 2, PageSize: 20}
+	if paging != wantPaging {
+		t.Errorf("TransferGetList returned paging %+v, want %+v", paging, wantPaging)
+	}
+}
+
+func TestTransferStatusIDString(t *testing.T) {
+	tests := map[TransferStatusID]string{
+		TransferStatusIDCreated:    "created",
+		TransferStatusIDInProgress: "in progress",
+		TransferStatusIDCompleted:  "completed",
+		TransferStatusIDCancelled:  "cancelled",
+		TransferStatusID(11):       "TransferStatusID(11)",
+	}
+	for id, want := range tests {
+		if got := id.String(); got != want {
+			t.Errorf("TransferStatusID(%d).String() = %q, want %q", int(id), got, want)
+		}
+	}
+}
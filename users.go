@@ -1,13 +1,122 @@
 package namecheap
 
 import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"math"
 	"net/url"
+	"strconv"
+	"strings"
 )
 
 const (
-	usersGetPricing = "namecheap.users.getPricing"
+	usersGetPricing            = "namecheap.users.getPricing"
+	usersGetBalances           = "namecheap.users.getBalances"
+	usersCreateAddFundsRequest = "namecheap.users.createaddfundsrequest"
+	usersGetAddFundsStatus     = "namecheap.users.getAddFundsStatus"
 )
 
+// Money is an amount in hundredths of its currency unit (e.g. cents), so
+// balances can be added and compared without floating point error.
+type Money int64
+
+// ParseMoney parses a decimal amount such as "4932.96" or "-5": digits with
+// an optional leading minus sign and an optional fraction. Digits past the
+// hundredths are rounded half away from zero. Exponents, NaN and Inf are
+// rejected.
+func ParseMoney(s string) (Money, error) {
+	digits := strings.TrimPrefix(s, "-")
+	whole, fraction, hasPoint := strings.Cut(digits, ".")
+	if !isDigits(whole) || hasPoint && !isDigits(fraction) {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+	units, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil || units > math.MaxInt64/100-1 {
+		return 0, fmt.Errorf("invalid amount %q: out of range", s)
+	}
+
+	cents := int64(0)
+	for i := 0; i < 2; i++ {
+		cents *= 10
+		if i < len(fraction) {
+			cents += int64(fraction[i] - '0')
+		}
+	}
+	m := units*100 + cents
+	if len(fraction) > 2 && fraction[2] >= '5' {
+		m++
+	}
+	if len(digits) < len(s) {
+		m = -m
+	}
+	return Money(m), nil
+}
+
+// isDigits reports whether s is a non-empty run of ASCII digits.
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *Money) UnmarshalXMLAttr(attr xml.Attr) error {
+	if attr.Value == "" {
+		*m = 0
+		return nil
+	}
+	parsed, err := ParseMoney(attr.Value)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// String formats the amount with two decimals, as the API expects it.
+func (m Money) String() string {
+	sign := ""
+	if m < 0 {
+		sign, m = "-", -m
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, m/100, m%100)
+}
+
+// Currency is an ISO 4217 currency code such as USD.
+type Currency string
+
+// UsersGetBalancesResult represents the data returned by 'users.getBalances'
+type UsersGetBalancesResult struct {
+	Currency                  Currency `xml:"Currency,attr"`
+	AvailableBalance          Money    `xml:"AvailableBalance,attr"`
+	AccountBalance            Money    `xml:"AccountBalance,attr"`
+	EarnedAmount              Money    `xml:"EarnedAmount,attr"`
+	WithdrawableAmount        Money    `xml:"WithdrawableAmount,attr"`
+	FundsRequiredForAutoRenew Money    `xml:"FundsRequiredForAutoRenew,attr"`
+}
+
+// UsersCreateAddFundsRequestResult represents the data returned by 'users.createaddfundsrequest'.
+// The user has to be sent to RedirectURL to complete the payment.
+type UsersCreateAddFundsRequestResult struct {
+	TokenID     string `xml:"TokenID,attr"`
+	ReturnURL   string `xml:"ReturnURL,attr"`
+	RedirectURL string `xml:"RedirectURL,attr"`
+}
+
+// UsersGetAddFundsStatusResult represents the data returned by 'users.getAddFundsStatus'
+type UsersGetAddFundsStatusResult struct {
+	TransactionID int   `xml:"TransactionID,attr"`
+	Amount        Money `xml:"Amount,attr"`
+	// Status is one of CREATED, COMPLETED or FAILED.
+	Status string `xml:"Status,attr"`
+}
+
 type UsersGetPricingResult struct {
 	ProductType     string `xml:"Name,attr"`
 	ProductCategory []struct {
@@ -28,6 +137,11 @@ type UsersGetPricingResult struct {
 }
 
 func (client *Client) UsersGetPricing(productType, productCategory, productName string) ([]UsersGetPricingResult, error) {
+	return client.UsersGetPricingContext(context.Background(), productType, productCategory, productName)
+}
+
+// UsersGetPricingContext is like UsersGetPricing but uses ctx for the underlying request.
+func (client *Client) UsersGetPricingContext(ctx context.Context, productType, productCategory, productName string) ([]UsersGetPricingResult, error) {
 	requestInfo := &ApiRequest{
 		command: usersGetPricing,
 		method:  "GET",
@@ -41,10 +155,83 @@ func (client *Client) UsersGetPricing(productType, productCategory, productName
 	if len(productName) > 0 && productName != "*" {
 		requestInfo.params.Set("ProductName", productName)
 	}
-	resp, err := client.do(requestInfo)
+	resp, err := client.do(ctx, requestInfo)
 	if err != nil {
 		return nil, err
 	}
 
 	return resp.UsersGetPricing, nil
 }
+
+func (client *Client) UsersGetBalances() (*UsersGetBalancesResult, error) {
+	return client.UsersGetBalancesContext(context.Background())
+}
+
+// UsersGetBalancesContext is like UsersGetBalances but uses ctx for the underlying request.
+func (client *Client) UsersGetBalancesContext(ctx context.Context) (*UsersGetBalancesResult, error) {
+	requestInfo := &ApiRequest{
+		command: usersGetBalances,
+		method:  "POST",
+		params:  url.Values{},
+	}
+
+	resp, err := client.do(ctx, requestInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.UsersGetBalances, nil
+}
+
+// UsersCreateAddFundsRequest starts adding amount to the account by credit
+// card. Namecheap sends the user back to returnURL once the payment at
+// RedirectURL is done; use UsersGetAddFundsStatus with the TokenID to check
+// the outcome.
+func (client *Client) UsersCreateAddFundsRequest(amount Money, returnURL string) (*UsersCreateAddFundsRequestResult, error) {
+	return client.UsersCreateAddFundsRequestContext(context.Background(), amount, returnURL)
+}
+
+// UsersCreateAddFundsRequestContext is like UsersCreateAddFundsRequest but uses ctx for the underlying request.
+func (client *Client) UsersCreateAddFundsRequestContext(ctx context.Context, amount Money, returnURL string) (*UsersCreateAddFundsRequestResult, error) {
+	if amount <= 0 {
+		return nil, fmt.Errorf("amount to add must be positive, got %v", amount)
+	}
+
+	requestInfo := &ApiRequest{
+		command: usersCreateAddFundsRequest,
+		method:  "POST",
+		params:  url.Values{},
+	}
+	requestInfo.params.Set("Username", client.userName(ctx))
+	requestInfo.params.Set("PaymentType", "Creditcard")
+	requestInfo.params.Set("Amount", amount.String())
+	requestInfo.params.Set("ReturnUrl", returnURL)
+
+	resp, err := client.do(ctx, requestInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.UsersCreateAddFundsRequest, nil
+}
+
+func (client *Client) UsersGetAddFundsStatus(tokenID string) (*UsersGetAddFundsStatusResult, error) {
+	return client.UsersGetAddFundsStatusContext(context.Background(), tokenID)
+}
+
+// UsersGetAddFundsStatusContext is like UsersGetAddFundsStatus but uses ctx for the underlying request.
+func (client *Client) UsersGetAddFundsStatusContext(ctx context.Context, tokenID string) (*UsersGetAddFundsStatusResult, error) {
+	requestInfo := &ApiRequest{
+		command: usersGetAddFundsStatus,
+		method:  "POST",
+		params:  url.Values{},
+	}
+	requestInfo.params.Set("TokenId", tokenID)
+
+	resp, err := client.do(ctx, requestInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.UsersGetAddFundsStatus, nil
+}
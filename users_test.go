@@ -0,0 +1,200 @@
+package namecheap
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Money
+		str  string
+	}{
+		{"4932.96", 493296, "4932.96"},
+		{"650.0000", 65000, "650.00"},
+		{"0", 0, "0.00"},
+		{"9.0200", 902, "9.02"},
+		{"-1.5", -150, "-1.50"},
+		{"10.985", 1099, "10.99"},
+		{"10.984", 1098, "10.98"},
+		{"-0.005", -1, "-0.01"},
+		{"7", 700, "7.00"},
+	}
+	for _, tt := range tests {
+		got, err := ParseMoney(tt.in)
+		if err != nil {
+			t.Errorf("ParseMoney(%q) returned error: %v", tt.in, err)
+		}
+		if got != tt.want {
+			t.Errorf("ParseMoney(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+		if got.String() != tt.str {
+			t.Errorf("Money(%d).String() = %q, want %q", got, got.String(), tt.str)
+		}
+	}
+
+	for _, in := range []string{"ten", "", "-", ".5", "1.", "+1", "1e3", "NaN", "Inf", "1,000.00", " 1", "1.2.3", "99999999999999999999"} {
+		if _, err := ParseMoney(in); err == nil {
+			t.Errorf("ParseMoney(%q) returned no error", in)
+		}
+	}
+}
+
+func TestUsersGetBalances(t *testing.T) {
+	setup()
+	defer teardown()
+
+	respXML := `<?xml version="1.0" encoding="UTF-8"?>
+<ApiResponse xmlns="http://api.namecheap.com/xml.response" Status="OK">
+  <Errors />
+  <RequestedCommand>namecheap.users.getBalances</RequestedCommand>
+  <CommandResponse Type="namecheap.users.getBalances">
+    <UserGetBalancesResult Currency="USD" AvailableBalance="4932.96" AccountBalance="4932.96" EarnedAmount="381.70" WithdrawableAmount="1243.36" FundsRequiredForAutoRenew="0.00" />
+  </CommandResponse>
+  <Server>SERVER-NAME</Server>
+  <GMTTimeDifference>+5</GMTTimeDifference>
+  <ExecutionTime>0.024</ExecutionTime>
+</ApiResponse>`
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		correctParams := fillDefaultParams(url.Values{})
+		correctParams.Set("Command", "namecheap.users.getBalances")
+		testBody(t, r, correctParams)
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, respXML)
+	})
+
+	balances, err := client.UsersGetBalances()
+	if err != nil {
+		t.Errorf("UsersGetBalances returned error: %v", err)
+	}
+	want := &UsersGetBalancesResult{
+		Currency:                  "USD",
+		AvailableBalance:          493296,
+		AccountBalance:            493296,
+		EarnedAmount:              38170,
+		WithdrawableAmount:        124336,
+		FundsRequiredForAutoRenew: 0,
+	}
+	if !reflect.DeepEqual(balances, want) {
+		t.Errorf("UsersGetBalances returned %+v, want %+v", balances, want)
+	}
+}
+
+func TestUsersCreateAddFundsRequest(t *testing.T) {
+	setup()
+	defer teardown()
+
+	respXML := `<?xml version="1.0" encoding="UTF-8"?>
+<ApiResponse xmlns="http://api.namecheap.com/xml.response" Status="OK">
+  <Errors />
+  <RequestedCommand>namecheap.users.createaddfundsrequest</RequestedCommand>
+  <CommandResponse Type="namecheap.users.createaddfundsrequest">
+    <Createaddfundsrequestresult TokenID="a1b2c3" ReturnURL="https://example.com/funds" RedirectURL="https://www.namecheap.com/myaccount/addfunds.aspx?token=a1b2c3" />
+  </CommandResponse>
+  <Server>SERVER-NAME</Server>
+  <GMTTimeDifference>+5</GMTTimeDifference>
+  <ExecutionTime>0.024</ExecutionTime>
+</ApiResponse>`
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		correctParams := fillDefaultParams(url.Values{})
+		correctParams.Set("Command", "namecheap.users.createaddfundsrequest")
+		correctParams.Set("Username", "anUser")
+		correctParams.Set("PaymentType", "Creditcard")
+		correctParams.Set("Amount", "25.50")
+		correctParams.Set("ReturnUrl", "https://example.com/funds")
+		testBody(t, r, correctParams)
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, respXML)
+	})
+
+	result, err := client.UsersCreateAddFundsRequest(2550, "https://example.com/funds")
+	if err != nil {
+		t.Errorf("UsersCreateAddFundsRequest returned error: %v", err)
+	}
+	want := &UsersCreateAddFundsRequestResult{
+		TokenID:     "a1b2c3",
+		ReturnURL:   "https://example.com/funds",
+		RedirectURL: "https://www.namecheap.com/myaccount/addfunds.aspx?token=a1b2c3",
+	}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("UsersCreateAddFundsRequest returned %+v, want %+v", result, want)
+	}
+
+	if _, err := client.UsersCreateAddFundsRequest(0, "https://example.com/funds"); err == nil {
+		t.Error("UsersCreateAddFundsRequest with zero amount returned no error")
+	}
+}
+
+func TestUsersCreateAddFundsRequestWithUserName(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("ParseForm returned error: %v", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if got := r.PostForm.Get("Username"); got != "subUser" {
+			t.Errorf("Username = %q, want the user from the context", got)
+		}
+		fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8"?>
+<ApiResponse xmlns="http://api.namecheap.com/xml.response" Status="OK">
+  <Errors />
+  <CommandResponse Type="namecheap.users.createaddfundsrequest">
+    <Createaddfundsrequestresult TokenID="a1b2c3" />
+  </CommandResponse>
+</ApiResponse>`)
+	})
+
+	ctx := WithUserName(context.Background(), "subUser")
+	if _, err := client.UsersCreateAddFundsRequestContext(ctx, 2550, "https://example.com/funds"); err != nil {
+		t.Errorf("UsersCreateAddFundsRequestContext returned error: %v", err)
+	}
+}
+
+func TestUsersGetAddFundsStatus(t *testing.T) {
+	setup()
+	defer teardown()
+
+	respXML := `<?xml version="1.0" encoding="UTF-8"?>
+<ApiResponse xmlns="http://api.namecheap.com/xml.response" Status="OK">
+  <Errors />
+  <RequestedCommand>namecheap.users.getAddFundsStatus</RequestedCommand>
+  <CommandResponse Type="namecheap.users.getAddFundsStatus">
+    <GetAddFundsStatusResult TransactionID="1717" Amount="25.50" Status="COMPLETED" />
+  </CommandResponse>
+  <Server>SERVER-NAME</Server>
+  <GMTTimeDifference>+5</GMTTimeDifference>
+  <ExecutionTime>0.024</ExecutionTime>
+</ApiResponse>`
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		correctParams := fillDefaultParams(url.Values{})
+		correctParams.Set("Command", "namecheap.users.getAddFundsStatus")
+		correctParams.Set("TokenId", "a1b2c3")
+		testBody(t, r, correctParams)
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, respXML)
+	})
+
+	result, err := client.UsersGetAddFundsStatus("a1b2c3")
+	if err != nil {
+		t.Errorf("UsersGetAddFundsStatus returned error: %v", err)
+	}
+	want := &UsersGetAddFundsStatusResult{
+		TransactionID: 1717,
+		Amount:        2550,
+		Status:        "COMPLETED",
+	}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("UsersGetAddFundsStatus returned %+v, want %+v", result, want)
+	}
+}
@@ -1,9 +1,11 @@
 package namecheap
 
 import (
+	"context"
 	"errors"
 	"net/url"
 	"strconv"
+	"time"
 )
 
 const (
@@ -16,11 +18,28 @@ const (
 type WhoisguardGetListResult struct {
 	ID         int64  `xml:"ID,attr"`
 	DomainName string `xml:"DomainName,attr"`
-	Created    string `xml:"Created,attr"`
-	Expires    string `xml:"Expires,attr"`
+	Created    Date   `xml:"Created,attr"`
+	Expires    Date   `xml:"Expires,attr"`
 	Status     string `xml:"Status,attr"`
 }
 
+// CreatedString returns Created as the API sent it.
+func (wg WhoisguardGetListResult) CreatedString() string {
+	return wg.Created.String()
+}
+
+// ExpiresString returns Expires as the API sent it.
+func (wg WhoisguardGetListResult) ExpiresString() string {
+	return wg.Expires.String()
+}
+
+// DaysUntilExpiry returns the number of days left before the WhoisGuard
+// subscription expires, negative once it has expired. Check Expires.IsZero
+// first, as for DomainGetListResult.DaysUntilExpiry.
+func (wg WhoisguardGetListResult) DaysUntilExpiry() int {
+	return wg.Expires.DaysUntil(time.Now())
+}
+
 type whoisguardEnableResult struct {
 	Domain    string `xml:"Domain,attr"`
 	IsSuccess bool   `xml:"IsSuccess,attr"`
@@ -40,13 +59,18 @@ type WhoisguardRenewResult struct {
 }
 
 func (client *Client) WhoisguardGetList() ([]WhoisguardGetListResult, error) {
+	return client.WhoisguardGetListContext(context.Background())
+}
+
+// WhoisguardGetListContext is like WhoisguardGetList but uses ctx for the underlying request.
+func (client *Client) WhoisguardGetListContext(ctx context.Context) ([]WhoisguardGetListResult, error) {
 	requestInfo := &ApiRequest{
 		command: whoisguardGetList,
 		method:  "POST",
 		params:  url.Values{},
 	}
 
-	resp, err := client.do(requestInfo)
+	resp, err := client.do(ctx, requestInfo)
 	if err != nil {
 		return nil, err
 	}
@@ -55,6 +79,11 @@ func (client *Client) WhoisguardGetList() ([]WhoisguardGetListResult, error) {
 }
 
 func (client *Client) WhoisguardEnable(id int64, email string) error {
+	return client.WhoisguardEnableContext(context.Background(), id, email)
+}
+
+// WhoisguardEnableContext is like WhoisguardEnable but uses ctx for the underlying request.
+func (client *Client) WhoisguardEnableContext(ctx context.Context, id int64, email string) error {
 	requestInfo := &ApiRequest{
 		command: whoisguardEnable,
 		method:  "POST",
@@ -63,7 +92,7 @@ func (client *Client) WhoisguardEnable(id int64, email string) error {
 
 	requestInfo.params.Set("WhoisguardID", strconv.FormatInt(id, 10))
 	requestInfo.params.Set("ForwardedToEmail", email)
-	resp, err := client.do(requestInfo)
+	resp, err := client.do(ctx, requestInfo)
 	if err == nil && !resp.WhoisguardEnable.IsSuccess {
 		err = errors.New("IsSuccess was false")
 	}
@@ -72,6 +101,11 @@ func (client *Client) WhoisguardEnable(id int64, email string) error {
 }
 
 func (client *Client) WhoisguardDisable(id int64) error {
+	return client.WhoisguardDisableContext(context.Background(), id)
+}
+
+// WhoisguardDisableContext is like WhoisguardDisable but uses ctx for the underlying request.
+func (client *Client) WhoisguardDisableContext(ctx context.Context, id int64) error {
 	requestInfo := &ApiRequest{
 		command: whoisguardDisable,
 		method:  "POST",
@@ -79,7 +113,7 @@ func (client *Client) WhoisguardDisable(id int64) error {
 	}
 
 	requestInfo.params.Set("WhoisguardID", strconv.FormatInt(id, 10))
-	resp, err := client.do(requestInfo)
+	resp, err := client.do(ctx, requestInfo)
 	if err == nil && !resp.WhoisguardDisable.IsSuccess {
 		err = errors.New("IsSuccess was false")
 	}
@@ -88,6 +122,15 @@ func (client *Client) WhoisguardDisable(id int64) error {
 }
 
 func (client *Client) WhoisguardRenew(id int64, years int) (*WhoisguardRenewResult, error) {
+	return client.WhoisguardRenewContext(context.Background(), id, years)
+}
+
+// WhoisguardRenewContext is like WhoisguardRenew but uses ctx for the underlying request.
+func (client *Client) WhoisguardRenewContext(ctx context.Context, id int64, years int) (*WhoisguardRenewResult, error) {
+	if err := client.checkSpending(); err != nil {
+		return nil, err
+	}
+
 	requestInfo := &ApiRequest{
 		command: whoisguardRenew,
 		method:  "POST",
@@ -96,7 +139,7 @@ func (client *Client) WhoisguardRenew(id int64, years int) (*WhoisguardRenewResu
 
 	requestInfo.params.Set("WhoisguardID", strconv.FormatInt(id, 10))
 	requestInfo.params.Set("Years", strconv.Itoa(years))
-	resp, err := client.do(requestInfo)
+	resp, err := client.do(ctx, requestInfo)
 	if err != nil {
 		return nil, err
 	}
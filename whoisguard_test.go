@@ -50,15 +50,15 @@ func TestWhoisguardGetList(t *testing.T) {
 	want := []WhoisguardGetListResult{
 		WhoisguardGetListResult{
 			ID:      34401,
-			Created: "12/18/2013",
-			Expires: "12/18/2014",
+			Created: mustParseDate(t, "12/18/2013"),
+			Expires: mustParseDate(t, "12/18/2014"),
 			Status:  "unused",
 		},
 		WhoisguardGetListResult{
 			ID:         34400,
 			DomainName: "test.com",
-			Created:    "12/26/2013",
-			Expires:    "12/26/2014",
+			Created:    mustParseDate(t, "12/26/2013"),
+			Expires:    mustParseDate(t, "12/26/2014"),
 			Status:     "enabled",
 		},
 	}
@@ -0,0 +1,406 @@
+package namecheap
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// maxTXTStringLength is the longest character-string a TXT record can hold;
+// longer values are split into several strings.
+const maxTXTStringLength = 255
+
+// namecheapRecordPrefix marks Namecheap-only records in an exported zone
+// file. They are written as comments so that other tools ignore them, and
+// ParseZone reads them back.
+const namecheapRecordPrefix = ";namecheap:"
+
+// ErrUnsupportedRecordType is returned by ParseZone for a record type that
+// Namecheap host records cannot hold.
+var ErrUnsupportedRecordType = errors.New("record type not supported by Namecheap")
+
+// ZoneError reports a problem on a line of a zone file.
+type ZoneError struct {
+	Line int
+	Err  error
+}
+
+func (err *ZoneError) Error() string {
+	return fmt.Sprintf("zone file line %d: %v", err.Line, err.Err)
+}
+
+func (err *ZoneError) Unwrap() error {
+	return err.Err
+}
+
+// zoneTypes are the types written as standard records; hostnameTypes among
+// them hold a hostname that must be made absolute. Namecheap's URL redirects
+// and MX easy records have no zone file equivalent and are written behind
+// namecheapRecordPrefix instead.
+var (
+	zoneTypes        = map[string]bool{"A": true, "AAAA": true, "CNAME": true, "MX": true, "TXT": true, "CAA": true, "NS": true, "ALIAS": true}
+	hostnameTypes    = map[string]bool{"CNAME": true, "MX": true, "NS": true, "ALIAS": true}
+	namecheapOnly    = map[string]bool{"URL": true, "URL301": true, "FRAME": true, "MXE": true}
+	ignoredZoneTypes = map[string]bool{"SOA": true}
+)
+
+// WriteZone writes the host records as an RFC 1035 zone file with the domain
+// as its origin.
+func (result *DomainDNSGetHostsResult) WriteZone(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "$ORIGIN %s.\n", strings.TrimSuffix(result.Domain, "."))
+	fmt.Fprintf(bw, "$TTL %d\n", DefaultHostTTL)
+
+	for _, h := range result.Hosts {
+		typ := strings.ToUpper(string(h.Type))
+		prefix := ""
+		switch {
+		case namecheapOnly[typ]:
+			prefix = namecheapRecordPrefix + " "
+		case !zoneTypes[typ]:
+			return fmt.Errorf("host %s: %w: %s", h.Name, ErrUnsupportedRecordType, h.Type)
+		}
+
+		name := h.Name
+		if name == "" {
+			name = "@"
+		}
+		fmt.Fprintf(bw, "%s%s\t%d\tIN\t%s\t%s\n", prefix, name, h.EffectiveTTL(), typ, zoneRData(typ, h))
+	}
+	return bw.Flush()
+}
+
+// zoneRData formats the data of a record for a zone file.
+func zoneRData(typ string, h DomainDNSHost) string {
+	switch {
+	case typ == "TXT" || namecheapOnly[typ] && typ != "MXE":
+		return quoteTXT(h.Address)
+	case typ == "MX":
+		return fmt.Sprintf("%d %s", h.MXPref, absoluteName(h.Address))
+	case hostnameTypes[typ]:
+		return absoluteName(h.Address)
+	}
+	return h.Address
+}
+
+func absoluteName(name string) string {
+	if name == "" || strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "."
+}
+
+// quoteTXT quotes s as one or more character-strings of at most 255 bytes.
+func quoteTXT(s string) string {
+	var parts []string
+	for {
+		chunk := s
+		if len(chunk) > maxTXTStringLength {
+			chunk = chunk[:maxTXTStringLength]
+		}
+		chunk = strings.ReplaceAll(chunk, `\`, `\\`)
+		chunk = strings.ReplaceAll(chunk, `"`, `\"`)
+		parts = append(parts, `"`+chunk+`"`)
+		if len(s) <= maxTXTStringLength {
+			return strings.Join(parts, " ")
+		}
+		s = s[maxTXTStringLength:]
+	}
+}
+
+// ParseZone reads a zone file for the domain origin into host records ready
+// for DomainDNSSetHosts. Names are made relative to origin, with "@" for the
+// apex; records without a TTL get the $TTL default or 1800. A TTL outside
+// MinTTL-MaxTTL, in $TTL or on a record, is an error. The SOA record and NS
+// records of the apex belong to the DNS provider and are skipped.
+// Any other type Namecheap cannot hold, SRV included since setHosts has no
+// SRV type, is reported as an error wrapping ErrUnsupportedRecordType.
+func ParseZone(r io.Reader, origin string) ([]DomainDNSHost, error) {
+	p := &zoneParser{
+		origin: strings.ToLower(absoluteName(origin)),
+		ttl:    DefaultHostTTL,
+	}
+	p.zone = p.origin
+
+	scanner := bufio.NewScanner(r)
+	var entry []zoneToken
+	depth, start, lineNo := 0, 0, 0
+	for scanner.Scan() {
+		lineNo++
+		line := scanner.Text()
+		if depth == 0 {
+			start = lineNo
+			if strings.HasPrefix(line, namecheapRecordPrefix) {
+				line = strings.TrimSpace(strings.TrimPrefix(line, namecheapRecordPrefix))
+			}
+		}
+		tokens, err := tokenizeZoneLine(line, &depth)
+		if err != nil {
+			return nil, &ZoneError{Line: lineNo, Err: err}
+		}
+		entry = append(entry, tokens...)
+		if depth > 0 {
+			continue
+		}
+		if err := p.parseEntry(entry); err != nil {
+			return nil, &ZoneError{Line: start, Err: err}
+		}
+		entry = entry[:0]
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if depth > 0 {
+		return nil, &ZoneError{Line: start, Err: errors.New("unbalanced parentheses")}
+	}
+	return p.hosts, nil
+}
+
+type zoneToken struct {
+	text   string
+	quoted bool
+	// blankOwner is set on the first token of a line that starts with
+	// whitespace, meaning the record reuses the previous owner name.
+	blankOwner bool
+}
+
+// tokenizeZoneLine splits one line into fields, dropping comments and
+// parentheses and tracking the parenthesis depth across lines.
+func tokenizeZoneLine(line string, depth *int) ([]zoneToken, error) {
+	var tokens []zoneToken
+	leadingSpace := *depth == 0 && len(line) > 0 && (line[0] == ' ' || line[0] == '\t')
+	for i := 0; i < len(line); {
+		c := line[i]
+		switch {
+		case c == ';':
+			i = len(line)
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+		case c == '(':
+			*depth++
+			i++
+		case c == ')':
+			if *depth == 0 {
+				return nil, errors.New("unbalanced parentheses")
+			}
+			*depth--
+			i++
+		case c == '"':
+			var b strings.Builder
+			i++
+			for ; i < len(line) && line[i] != '"'; i++ {
+				if line[i] == '\\' && i+1 < len(line) {
+					i++
+				}
+				b.WriteByte(line[i])
+			}
+			if i == len(line) {
+				return nil, errors.New("unterminated quoted string")
+			}
+			i++
+			tokens = append(tokens, zoneToken{text: b.String(), quoted: true})
+		default:
+			j := i
+			for j < len(line) && !strings.ContainsRune(" \t\r;()\"", rune(line[j])) {
+				j++
+			}
+			tokens = append(tokens, zoneToken{text: line[i:j]})
+			i = j
+		}
+	}
+	if leadingSpace && len(tokens) > 0 {
+		tokens[0].blankOwner = true
+	}
+	return tokens, nil
+}
+
+type zoneParser struct {
+	origin string // the domain, absolute and lower case
+	zone   string // the current $ORIGIN
+	ttl    int    // the current $TTL
+	owner  string // the owner of the previous record, relative to origin
+	hosts  []DomainDNSHost
+}
+
+func (p *zoneParser) parseEntry(tokens []zoneToken) error {
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	switch strings.ToUpper(tokens[0].text) {
+	case "$ORIGIN":
+		if len(tokens) != 2 {
+			return errors.New("$ORIGIN needs one domain name")
+		}
+		p.zone = strings.ToLower(p.absolute(tokens[1].text))
+		return nil
+	case "$TTL":
+		if len(tokens) != 2 {
+			return errors.New("$TTL needs one value")
+		}
+		ttl, err := parseTTL(tokens[1].text)
+		if err != nil {
+			return err
+		}
+		if err := checkZoneTTL(ttl); err != nil {
+			return fmt.Errorf("$TTL: %w", err)
+		}
+		p.ttl = ttl
+		return nil
+	case "$INCLUDE", "$GENERATE":
+		return fmt.Errorf("%s is not supported", tokens[0].text)
+	}
+
+	owner := p.owner
+	if !tokens[0].blankOwner {
+		var err error
+		if owner, err = p.relative(tokens[0].text); err != nil {
+			return err
+		}
+		tokens = tokens[1:]
+	}
+	if owner == "" {
+		return errors.New("record has no owner name")
+	}
+	p.owner = owner
+
+	// The TTL and class may come in either order before the type.
+	ttl := p.ttl
+	for len(tokens) > 0 {
+		if strings.EqualFold(tokens[0].text, "IN") {
+			tokens = tokens[1:]
+		} else if t, err := parseTTL(tokens[0].text); err == nil {
+			ttl = t
+			tokens = tokens[1:]
+		} else {
+			break
+		}
+	}
+	if len(tokens) == 0 {
+		return errors.New("record has no type")
+	}
+	typ := strings.ToUpper(tokens[0].text)
+	rdata := tokens[1:]
+
+	if ignoredZoneTypes[typ] || typ == "NS" && owner == "@" {
+		return nil
+	}
+	if err := checkZoneTTL(ttl); err != nil {
+		return err
+	}
+	if typ == "SRV" {
+		return fmt.Errorf("%w: SRV records cannot be set through the Namecheap API", ErrUnsupportedRecordType)
+	}
+	if !zoneTypes[typ] && !namecheapOnly[typ] {
+		return fmt.Errorf("%w: %s", ErrUnsupportedRecordType, typ)
+	}
+
+	host := DomainDNSHost{Name: owner, Type: RecordType(typ), TTL: ttl}
+	switch {
+	case typ == "TXT" || namecheapOnly[typ] && typ != "MXE":
+		if len(rdata) == 0 {
+			return fmt.Errorf("%s record has no data", typ)
+		}
+		var b strings.Builder
+		for _, t := range rdata {
+			b.WriteString(t.text)
+		}
+		host.Address = b.String()
+	case typ == "MX":
+		if len(rdata) != 2 {
+			return errors.New("MX record needs a preference and a mail server")
+		}
+		pref, err := strconv.Atoi(rdata[0].text)
+		if err != nil || pref < 0 || pref > 65535 {
+			return fmt.Errorf("invalid MX preference %q", rdata[0].text)
+		}
+		host.MXPref = pref
+		host.Address = p.absolute(rdata[1].text)
+	case hostnameTypes[typ]:
+		if len(rdata) != 1 {
+			return fmt.Errorf("%s record needs one hostname", typ)
+		}
+		host.Address = p.absolute(rdata[0].text)
+	case typ == "CAA":
+		if len(rdata) != 3 {
+			return errors.New("CAA record needs flags, tag and value")
+		}
+		host.Address = fmt.Sprintf("%s %s %s", rdata[0].text, rdata[1].text, quoteTXT(rdata[2].text))
+	default:
+		if len(rdata) != 1 {
+			return fmt.Errorf("%s record needs one address", typ)
+		}
+		host.Address = rdata[0].text
+	}
+
+	p.hosts = append(p.hosts, host)
+	return nil
+}
+
+// absolute makes name a fully qualified name with a trailing dot.
+func (p *zoneParser) absolute(name string) string {
+	switch {
+	case name == "@":
+		return p.zone
+	case strings.HasSuffix(name, "."):
+		return name
+	}
+	return name + "." + p.zone
+}
+
+// relative returns name as a Namecheap host name relative to the origin.
+func (p *zoneParser) relative(name string) (string, error) {
+	abs := strings.ToLower(p.absolute(name))
+	if abs == p.origin {
+		return "@", nil
+	}
+	if !strings.HasSuffix(abs, "."+p.origin) {
+		return "", fmt.Errorf("name %q is outside the zone %s", name, p.origin)
+	}
+	return strings.TrimSuffix(abs, "."+p.origin), nil
+}
+
+// checkZoneTTL rejects a TTL Namecheap would not accept, so that ParseZone
+// only returns records DomainDNSSetHosts can write.
+func checkZoneTTL(ttl int) error {
+	if ttl < MinTTL || ttl > MaxTTL {
+		return fmt.Errorf("TTL %d is outside the %d-%d seconds Namecheap accepts", ttl, MinTTL, MaxTTL)
+	}
+	return nil
+}
+
+// parseTTL parses a TTL in seconds or in BIND's unit notation, e.g. 1h30m.
+func parseTTL(s string) (int, error) {
+	if s == "" {
+		return 0, errors.New("empty TTL")
+	}
+	if n, err := strconv.Atoi(s); err == nil {
+		if n < 0 {
+			return 0, fmt.Errorf("invalid TTL %q", s)
+		}
+		return n, nil
+	}
+
+	units := map[byte]int{'s': 1, 'm': 60, 'h': 3600, 'd': 86400, 'w': 604800}
+	total, n, digits := 0, 0, false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= '0' && c <= '9':
+			n = n*10 + int(c-'0')
+			digits = true
+		case units[c|0x20] != 0 && digits:
+			total += n * units[c|0x20]
+			n, digits = 0, false
+		default:
+			return 0, fmt.Errorf("invalid TTL %q", s)
+		}
+	}
+	if digits {
+		return 0, fmt.Errorf("invalid TTL %q", s)
+	}
+	return total, nil
+}
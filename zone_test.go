@@ -0,0 +1,162 @@
+package namecheap
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWriteZone(t *testing.T) {
+	result := &DomainDNSGetHostsResult{
+		Domain: "domain.com",
+		Hosts: []DomainDNSHost{
+			{Name: "@", Type: "A", Address: "1.2.3.4", TTL: 1800},
+			{Name: "www", Type: "CNAME", Address: "domain.com", TTL: 300},
+			{Name: "@", Type: "MX", Address: "mx.example.com.", MXPref: 10, TTL: 1800},
+			{Name: "@", Type: "TXT", Address: `v=spf1 include:"x" -all`, TTL: 1800},
+			{Name: "@", Type: "CAA", Address: `0 issue "letsencrypt.org"`, TTL: 1800},
+			{Name: "go", Type: "URL301", Address: "https://example.com/", TTL: 1800},
+		},
+	}
+
+	var b strings.Builder
+	if err := result.WriteZone(&b); err != nil {
+		t.Fatalf("WriteZone returned error: %v", err)
+	}
+
+	want := `$ORIGIN domain.com.
+$TTL 1800
+@	1800	IN	A	1.2.3.4
+www	300	IN	CNAME	domain.com.
+@	1800	IN	MX	10 mx.example.com.
+@	1800	IN	TXT	"v=spf1 include:\"x\" -all"
+@	1800	IN	CAA	0 issue "letsencrypt.org"
+;namecheap: go	1800	IN	URL301	"https://example.com/"
+`
+	if got := b.String(); got != want {
+		t.Errorf("WriteZone wrote\n%s\nwant\n%s", got, want)
+	}
+
+	parsed, err := ParseZone(strings.NewReader(b.String()), "domain.com")
+	if err != nil {
+		t.Fatalf("ParseZone returned error: %v", err)
+	}
+	if !SameHosts(parsed, result.Hosts) {
+		t.Errorf("ParseZone of exported zone returned %+v, want %+v", parsed, result.Hosts)
+	}
+}
+
+func TestWriteZoneSplitsLongTXT(t *testing.T) {
+	key := strings.Repeat("a", 300)
+	result := &DomainDNSGetHostsResult{
+		Domain: "domain.com",
+		Hosts:  []DomainDNSHost{{Name: "dkim._domainkey", Type: "TXT", Address: key}},
+	}
+
+	var b strings.Builder
+	if err := result.WriteZone(&b); err != nil {
+		t.Fatalf("WriteZone returned error: %v", err)
+	}
+	if !strings.Contains(b.String(), `"`+strings.Repeat("a", 255)+`" "`+strings.Repeat("a", 45)+`"`) {
+		t.Errorf("WriteZone did not split the TXT value:\n%s", b.String())
+	}
+
+	parsed, err := ParseZone(strings.NewReader(b.String()), "domain.com")
+	if err != nil {
+		t.Fatalf("ParseZone returned error: %v", err)
+	}
+	if len(parsed) != 1 || parsed[0].Address != key {
+		t.Errorf("ParseZone did not join the TXT strings: %+v", parsed)
+	}
+}
+
+func TestParseZone(t *testing.T) {
+	zone := `$ORIGIN domain.com.
+$TTL 1h
+@	IN	SOA	ns1.registrar.com. hostmaster.domain.com. (
+		2024010101 ; serial
+		7200 3600 1209600 3600 )
+@		IN	NS	ns1.registrar.com.
+		IN	A	1.2.3.4
+		IN	MX	10 mail
+www	300	IN	CNAME	@
+Blog.domain.com. IN 600 AAAA 2001:db8::1
+dev		IN	NS	ns1.other.net.
+@		IN	TXT	"hello " "world" ; greeting
+$ORIGIN api.domain.com.
+v1	IN	ALIAS	lb.example.net.
+`
+
+	hosts, err := ParseZone(strings.NewReader(zone), "domain.com")
+	if err != nil {
+		t.Fatalf("ParseZone returned error: %v", err)
+	}
+
+	want := []DomainDNSHost{
+		{Name: "@", Type: "A", Address: "1.2.3.4", TTL: 3600},
+		{Name: "@", Type: "MX", Address: "mail.domain.com.", MXPref: 10, TTL: 3600},
+		{Name: "www", Type: "CNAME", Address: "domain.com.", TTL: 300},
+		{Name: "blog", Type: "AAAA", Address: "2001:db8::1", TTL: 600},
+		{Name: "dev", Type: "NS", Address: "ns1.other.net.", TTL: 3600},
+		{Name: "@", Type: "TXT", Address: "hello world", TTL: 3600},
+		{Name: "v1.api", Type: "ALIAS", Address: "lb.example.net.", TTL: 3600},
+	}
+	if !reflect.DeepEqual(hosts, want) {
+		t.Errorf("ParseZone returned %+v, want %+v", hosts, want)
+	}
+}
+
+func TestParseZoneErrors(t *testing.T) {
+	cases := []struct {
+		zone string
+		line int
+		kind error
+	}{
+		{"@ IN A 1.2.3.4\n4 IN PTR host.domain.com.\n", 2, ErrUnsupportedRecordType},
+		{"@ IN DS 1 2 3 abcd\n", 1, ErrUnsupportedRecordType},
+		{"_sip._tcp IN SRV 10 5 5060 sip.domain.com.\n", 1, ErrUnsupportedRecordType},
+		{"www.other.com. IN A 1.2.3.4\n", 1, nil},
+		{"@ IN MX mail.domain.com.\n", 1, nil},
+		{"@ IN TXT \"unterminated\n", 1, nil},
+		{"@ IN SOA ns1. host. ( 1 2 3\n", 1, nil},
+		{"$ORIGIN domain.com.\n$TTL 86400\n@ IN A 1.2.3.4\n", 2, nil},
+		{"@ IN A 1.2.3.4\nwww 30 IN A 1.2.3.4\n", 2, nil},
+		{"@ IN 1d A 1.2.3.4\n", 1, nil},
+		{"@ 86400 IN SOA ns1. host. 1 2 3 4 5\n@ IN TXT \"x\" \"y\"\nwww 0 IN CNAME @\n", 3, nil},
+	}
+
+	for _, c := range cases {
+		_, err := ParseZone(strings.NewReader(c.zone), "domain.com")
+		var zoneErr *ZoneError
+		if !errors.As(err, &zoneErr) {
+			t.Errorf("ParseZone(%q) returned %v, want a *ZoneError", c.zone, err)
+			continue
+		}
+		if zoneErr.Line != c.line {
+			t.Errorf("ParseZone(%q) reported line %d, want %d", c.zone, zoneErr.Line, c.line)
+		}
+		if c.kind != nil && !errors.Is(err, c.kind) {
+			t.Errorf("ParseZone(%q) returned %v, want %v", c.zone, err, c.kind)
+		}
+	}
+
+	_, err := ParseZone(strings.NewReader("@ IN A 1.2.3.4\n_sip._tcp IN SRV 10 5 5060 sip.domain.com.\n"), "domain.com")
+	if err == nil || !strings.Contains(err.Error(), "line 2: ") || !strings.Contains(err.Error(), "SRV records cannot be set") {
+		t.Errorf("ParseZone of an SRV record returned %v, want a line 2 error saying SRV is not supported", err)
+	}
+}
+
+func TestParseTTL(t *testing.T) {
+	cases := map[string]int{"300": 300, "1h": 3600, "1h30m": 5400, "1W": 604800, "2d": 172800}
+	for in, want := range cases {
+		if got, err := parseTTL(in); err != nil || got != want {
+			t.Errorf("parseTTL(%q) = %d, %v, want %d", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "h", "1x", "10m5", "-1"} {
+		if _, err := parseTTL(in); err == nil {
+			t.Errorf("parseTTL(%q) should have returned an error", in)
+		}
+	}
+}